@@ -0,0 +1,243 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	gosort "sort"
+	"text/tabwriter"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+)
+
+var auditRepoPath string
+
+// auditTagsCmd represents the audit-tags command
+var auditTagsCmd = &cobra.Command{
+	Use:   "audit-tags",
+	Short: "Find malformed, duplicate and out-of-order version tags",
+	Long: `Check the version tags in a git repository and list any problems:
+
+	invalid       a tag that looks like a version but isn't valid semver, e.g. v1.2.3.4
+	duplicate     tags for the same version, e.g. v1.0.0 and 1.0.0
+	out-of-order  a higher version tagged on an ancestor of a lower version's commit
+
+Exits with a non-zero status if any problems are found.`,
+	Run: runAuditTags,
+}
+
+func init() {
+	auditTagsCmd.Flags().StringVarP(&auditRepoPath, "repository", "r", ".", "Path to the git repository (defaults to current directory)")
+}
+
+// tagProblem is a problem found with a tag
+type tagProblem struct {
+	Kind   string
+	Tag    string
+	Detail string
+}
+
+type auditedTag struct {
+	name    string
+	version *semver.Version
+	commit  plumbing.Hash
+}
+
+// ancestorsOf returns the set of commits reachable from a commit, including itself
+func ancestorsOf(repo *goget.Repository, from plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	ancestors := make(map[plumbing.Hash]bool)
+	commitIter, err := repo.Log(&goget.LogOptions{From: from})
+	if err != nil {
+		return nil, fmt.Errorf("error getting commit history: %w", err)
+	}
+	defer commitIter.Close()
+	for {
+		c, err := commitIter.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("error walking commit history: %w", err)
+		}
+		ancestors[c.Hash] = true
+	}
+	return ancestors, nil
+}
+
+// highestAncestorVersions walks the history of the tagged commits once, parents before
+// children, and returns the highest version tagged on each commit or its ancestors,
+// along with the parents of each commit
+func highestAncestorVersions(repo *goget.Repository, tags []auditedTag) (map[plumbing.Hash]*semver.Version, map[plumbing.Hash][]plumbing.Hash, error) {
+	tagged := make(map[plumbing.Hash][]*semver.Version)
+	for _, t := range tags {
+		tagged[t.commit] = append(tagged[t.commit], t.version)
+	}
+
+	highest := make(map[plumbing.Hash]*semver.Version)
+	parents := make(map[plumbing.Hash][]plumbing.Hash)
+	done := make(map[plumbing.Hash]bool)
+	type frame struct {
+		hash     plumbing.Hash
+		expanded bool
+	}
+	for _, t := range tags {
+		stack := []frame{{hash: t.commit}}
+		for len(stack) > 0 {
+			f := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if done[f.hash] {
+				continue
+			}
+			if !f.expanded {
+				c, err := repo.CommitObject(f.hash)
+				if err != nil {
+					return nil, nil, fmt.Errorf("error walking commit history: %w", err)
+				}
+				parents[f.hash] = c.ParentHashes
+				stack = append(stack, frame{hash: f.hash, expanded: true})
+				for _, p := range c.ParentHashes {
+					if !done[p] {
+						stack = append(stack, frame{hash: p})
+					}
+				}
+				continue
+			}
+			var max *semver.Version
+			for _, v := range tagged[f.hash] {
+				if max == nil || v.GreaterThan(max) {
+					max = v
+				}
+			}
+			for _, p := range parents[f.hash] {
+				if v := highest[p]; v != nil && (max == nil || v.GreaterThan(max)) {
+					max = v
+				}
+			}
+			if max != nil {
+				highest[f.hash] = max
+			}
+			done[f.hash] = true
+		}
+	}
+	return highest, parents, nil
+}
+
+// auditTags checks every tag in the repository, returning the problems found in tag
+// name order
+func auditTags(repo *goget.Repository) ([]tagProblem, error) {
+	iter, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("error getting tags: %w", err)
+	}
+	defer iter.Close()
+
+	problems := make([]tagProblem, 0)
+	tags := make([]auditedTag, 0)
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		v, err := semver.NewVersion(name)
+		if err != nil {
+			if versionLikeTag.MatchString(name) {
+				problems = append(problems, tagProblem{Kind: "invalid", Tag: name, Detail: err.Error()})
+			}
+			return nil
+		}
+		commit, err := getTagCommit(repo, name)
+		if err != nil {
+			return fmt.Errorf("error getting commit for tag %s: %w", name, err)
+		}
+		tags = append(tags, auditedTag{name: name, version: v, commit: commit})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	gosort.Slice(tags, func(i, j int) bool { return tags[i].name < tags[j].name })
+
+	// Tags for the same version, e.g. v1.0.0 and 1.0.0
+	byVersion := make(map[string][]string)
+	for _, t := range tags {
+		byVersion[t.version.String()] = append(byVersion[t.version.String()], t.name)
+	}
+	for _, t := range tags {
+		if names := byVersion[t.version.String()]; len(names) > 1 && names[0] != t.name {
+			problems = append(problems, tagProblem{Kind: "duplicate", Tag: t.name, Detail: fmt.Sprintf("same version as %s", names[0])})
+		}
+	}
+
+	// A higher version on an ancestor of a lower version's commit. Only the tags with
+	// a higher version somewhere in their history have their ancestors looked up.
+	highest, parents, err := highestAncestorVersions(repo, tags)
+	if err != nil {
+		return nil, err
+	}
+	for _, lower := range tags {
+		var inherited *semver.Version
+		for _, p := range parents[lower.commit] {
+			if v := highest[p]; v != nil && (inherited == nil || v.GreaterThan(inherited)) {
+				inherited = v
+			}
+		}
+		if inherited == nil || !inherited.GreaterThan(lower.version) {
+			continue
+		}
+		ancestors, err := ancestorsOf(repo, lower.commit)
+		if err != nil {
+			return nil, err
+		}
+		for _, higher := range tags {
+			if higher.commit != lower.commit && higher.version.GreaterThan(lower.version) && ancestors[higher.commit] {
+				problems = append(problems, tagProblem{
+					Kind:   "out-of-order",
+					Tag:    higher.name,
+					Detail: fmt.Sprintf("tagged on an ancestor of %s", lower.name),
+				})
+			}
+		}
+	}
+
+	gosort.SliceStable(problems, func(i, j int) bool { return problems[i].Tag < problems[j].Tag })
+	return problems, nil
+}
+
+func writeTagProblems(out io.Writer, problems []tagProblem) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROBLEM\tTAG\tDETAIL")
+	for _, p := range problems {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", p.Kind, p.Tag, p.Detail)
+	}
+	return w.Flush()
+}
+
+func runAuditTags(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Unexpected arguments: %v\n", args)
+		_ = cmd.Help()
+		os.Exit(1)
+	}
+
+	repo, err := goget.PlainOpenWithOptions(auditRepoPath, &goget.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open git repository: %s\n", err)
+		os.Exit(1)
+	}
+
+	problems, err := auditTags(repo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error auditing tags: %s\n", err)
+		os.Exit(1)
+	}
+	if len(problems) == 0 {
+		fmt.Println("No problems found")
+		return
+	}
+	if err := writeTagProblems(os.Stdout, problems); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+	}
+	os.Exit(1)
+}
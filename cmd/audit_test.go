@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTag(t *testing.T) {
+	v, err := parseTag("v1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", v.String())
+
+	// Tags that aren't versions are ignored
+	v, err = parseTag("release-notes")
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	// Malformed version tags are skipped with a warning
+	v, err = parseTag("v1.2.3.4")
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestParseTagStrict(t *testing.T) {
+	strictTags = true
+	defer func() { strictTags = false }()
+
+	_, err := parseTag("v1.2.3.4")
+	assert.ErrorIs(t, err, ErrMalformedTag)
+
+	v, err := parseTag("release-notes")
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestGetTagsStrict(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0", commit, nil)
+	assert.NoError(t, err)
+
+	tags, err := getTags(repo)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2) // v1.0 is coerced to 1.0.0 by the loose parser
+
+	_, err = repo.CreateTag("v1.0.0.1", commit, nil)
+	assert.NoError(t, err)
+	tags, err = getTags(repo)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+
+	strictTags = true
+	defer func() { strictTags = false }()
+	_, err = getTags(repo)
+	assert.ErrorIs(t, err, ErrMalformedTag)
+}
+
+func TestAuditTags(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+
+	commit1, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v2.0.0", commit1, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit1, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", commit1, nil)
+	assert.NoError(t, err)
+
+	commit2, err := commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.5.0", commit2, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.6.0.1", commit2, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("nightly", commit2, nil)
+	assert.NoError(t, err)
+
+	problems, err := auditTags(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []tagProblem{
+		{Kind: "duplicate", Tag: "v1.0.0", Detail: "same version as 1.0.0"},
+		{Kind: "invalid", Tag: "v1.6.0.1", Detail: "Invalid Semantic Version"},
+		{Kind: "out-of-order", Tag: "v2.0.0", Detail: "tagged on an ancestor of v1.5.0"},
+	}, problems)
+}
+
+func TestAuditTagsClean(t *testing.T) {
+	repo := setupRepoWithTags(t)
+
+	problems, err := auditTags(repo)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestAuditTagsAcrossMerge(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	base, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", base, nil)
+	assert.NoError(t, err)
+	branch, err := commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v3.0.0", branch, nil)
+	assert.NoError(t, err)
+
+	// v2.1.0 and v3.0.0 are on sibling commits, which is fine, but the merge brings
+	// both into the history of v2.0.0
+	w, err := repo.Worktree()
+	assert.NoError(t, err)
+	main, err := w.Commit("Work on main", &git.CommitOptions{Parents: []plumbing.Hash{base}})
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v2.1.0", main, nil)
+	assert.NoError(t, err)
+	merge, err := w.Commit("Merge the branch", &git.CommitOptions{Parents: []plumbing.Hash{main, branch}})
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v2.0.0", merge, nil)
+	assert.NoError(t, err)
+
+	problems, err := auditTags(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []tagProblem{
+		{Kind: "out-of-order", Tag: "v2.1.0", Detail: "tagged on an ancestor of v2.0.0"},
+		{Kind: "out-of-order", Tag: "v3.0.0", Detail: "tagged on an ancestor of v2.0.0"},
+	}, problems)
+}
@@ -5,9 +5,11 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
-	// "golang.org/x/mod/semver"
-
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -39,8 +41,26 @@ var bumpCmd = &cobra.Command{
 	semvertool bump 1.1.1-alpha.2+3f6d1270
 	1.1.1
 
+	semvertool bump --prerelease --metadata build.5 1.1.1-alpha.1
+	1.1.1-alpha.2+build.5
+
+	semvertool bump --prerelease --keep-metadata 1.1.1-alpha.2+build.5
+	1.1.1-alpha.3+build.5
+
+	semvertool bump --metadata-only --metadata build.6 1.1.1-alpha.3+build.5
+	1.1.1-alpha.3+build.6
+
 	semvertool bump --prerelease --prerelease-prefix snapshot 1.1.1
 	1.1.2-snapshot.1
+
+	semvertool bump --major --initial-development 0.4.2
+	0.5.0
+
+	semvertool bump --release-1.0 0.5.0
+	1.0.0
+
+	semvertool bump --scheme calver:YYYY.MM.MICRO 2024.12.3
+	2025.1.0
 	`,
 	Run: runBump,
 }
@@ -51,7 +71,63 @@ func init() {
 	cf := getCommonBumpFlags()
 	bumpCmd.Flags().AddFlagSet(cf)
 	bumpCmd.Flags().StringP("metadata", "", "", "Append the given string to the version as metadata.")
-	bumpCmd.MarkFlagsMutuallyExclusive("major", "minor", "patch", "prerelease", "from-message")
+	bumpCmd.Flags().Bool("metadata-only", false, "Only set the metadata given with --metadata, without bumping the version.")
+	bumpCmd.Flags().Bool("keep-metadata", false, "Keep the existing metadata instead of clearing it when bumping.")
+	bumpCmd.MarkFlagsMutuallyExclusive("major", "minor", "patch", "prerelease", "from-message", "metadata-only", "release-1.0")
+	bumpCmd.MarkFlagsMutuallyExclusive("metadata-only", "keep-metadata")
+}
+
+// bumpWithMetadata bumps a version, then applies the metadata options. The metadata
+// given with --metadata replaces any other metadata; otherwise the metadata of the old
+// version is kept with --keep-metadata, and cleared by the bump if not. With
+// --metadata-only the version isn't bumped at all.
+func bumpWithMetadata(oldV string, bumpType BumpType, target *semver.Version) (*semver.Version, error) {
+	metadata := viper.GetString("metadata")
+	if metadata != "" {
+		if err := validateMetadata(metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	var newV *semver.Version
+	if viper.GetBool("metadata-only") {
+		if metadata == "" {
+			return nil, fmt.Errorf("--metadata-only needs --metadata")
+		}
+		v, err := semver.NewVersion(oldV)
+		if err != nil {
+			return nil, err
+		}
+		newV = v
+	} else if bumpType == ExplicitBump {
+		v, err := explicitBump(oldV, target)
+		if err != nil {
+			return nil, err
+		}
+		newV = v
+	} else {
+		v, err := doBump(oldV, bumpType)
+		if err != nil {
+			return nil, err
+		}
+		newV = v
+	}
+
+	if metadata == "" && viper.GetBool("keep-metadata") && oldV != "" {
+		old, err := semver.NewVersion(oldV)
+		if err != nil {
+			return nil, err
+		}
+		metadata = old.Metadata()
+	}
+	if metadata == "" {
+		return newV, nil
+	}
+	v, err := newV.SetMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
 }
 
 func runBump(cmd *cobra.Command, args []string) {
@@ -61,7 +137,9 @@ func runBump(cmd *cobra.Command, args []string) {
 	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
 		_ = viper.BindPFlag(flag.Name, flag)
 	})
-	if len(args) < 1 {
+	// A calendar version can be started from scratch, so it doesn't need a previous version
+	isCalver := strings.HasPrefix(viper.GetString("scheme"), calverSchemePrefix)
+	if len(args) < 1 && !isCalver {
 		_ = cmd.Help()
 		return
 	}
@@ -71,13 +149,59 @@ func runBump(cmd *cobra.Command, args []string) {
 		_ = cmd.Help()
 		return
 	}
-	oldV := args[0]
-	bumpType := getBumpType()
-	newV, err := doBump(oldV, bumpType)
+	oldV := ""
+	if len(args) == 1 {
+		oldV = args[0]
+	}
+	bumpType, target, err := getBumpTarget()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error bumping version:", err)
+		os.Exit(1)
+	}
+	bumpType, err = initialDevelopmentBump(oldV, bumpType)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error bumping version:", err)
+		os.Exit(1)
+	}
+	newV, err := bumpWithMetadata(oldV, bumpType, target)
 	if err != nil {
-		fmt.Println("Error bumping version:", err)
+		fmt.Fprintln(os.Stderr, "Error bumping version:", err)
+		os.Exit(1)
+	}
+
+	result := &bumpResult{Current: newV, BumpType: bumpType}
+	if oldV != "" {
+		result.Previous = semver.MustParse(oldV)
+		if bumpType == ExplicitBump {
+			result.BumpType = bumpTypeBetween(result.Previous, newV)
+		}
+	}
+	if viper.GetBool("metadata-only") {
+		result.BumpType = NoBump
+	}
+	// The repository is only needed to find the branch, so it's fine if there isn't one
+	repo, _ := goget.PlainOpenWithOptions(".", &goget.PlainOpenOptions{DetectDotGit: true})
+	if err := enforcePolicy(repo, result); err != nil {
+		fmt.Fprintln(os.Stderr, "Error bumping version:", err)
+		os.Exit(1)
+	}
+
+	if viper.GetBool("dry-run") {
+		plan, err := planRelease(repo, result)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Could not plan the release:", err)
+			os.Exit(1)
+		}
+		_ = writeReleasePlan(os.Stdout, plan)
 		return
 	}
 
-	fmt.Println(newV)
+	fmt.Println(printedVersion(newV))
+
+	if viper.GetBool("ci-output") {
+		if err := writeCIOutput(os.Stdout, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not write CI output: %s\n", err)
+			os.Exit(1)
+		}
+	}
 }
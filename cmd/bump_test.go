@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBumpWithMetadataAppliesMetadata(t *testing.T) {
+	viper.Reset()
+	viper.Set("metadata", "build.5")
+	result, err := bumpWithMetadata("1.0.0", PatchBump, nil)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1+build.5", result.String())
+}
+
+func TestBumpWithMetadataReplacesExistingMetadata(t *testing.T) {
+	viper.Reset()
+	viper.Set("metadata", "build.6")
+	result, err := bumpWithMetadata("1.0.0-alpha.1+build.5", PrereleaseBump, nil)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0-alpha.2+build.6", result.String())
+}
+
+func TestBumpWithMetadataClearsByDefault(t *testing.T) {
+	viper.Reset()
+	result, err := bumpWithMetadata("1.0.0-alpha.1+build.5", PrereleaseBump, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0-alpha.2", result.String())
+}
+
+func TestBumpWithMetadataKeepMetadata(t *testing.T) {
+	viper.Reset()
+	viper.Set("keep-metadata", true)
+	result, err := bumpWithMetadata("1.0.0-alpha.1+build.5", PrereleaseBump, nil)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0-alpha.2+build.5", result.String())
+}
+
+func TestBumpWithMetadataOnly(t *testing.T) {
+	viper.Reset()
+	viper.Set("metadata-only", true)
+	viper.Set("metadata", "build.6")
+	result, err := bumpWithMetadata("v1.0.0-alpha.1+build.5", PatchBump, nil)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0-alpha.1+build.6", result.Original())
+}
+
+func TestBumpWithMetadataOnlyNeedsMetadata(t *testing.T) {
+	viper.Reset()
+	viper.Set("metadata-only", true)
+	_, err := bumpWithMetadata("1.0.0", PatchBump, nil)
+	viper.Reset()
+	assert.Error(t, err)
+}
+
+func TestBumpWithMetadataInvalid(t *testing.T) {
+	viper.Reset()
+	viper.Set("metadata", "build..5")
+	_, err := bumpWithMetadata("1.0.0", PatchBump, nil)
+	viper.Reset()
+	assert.ErrorIs(t, err, ErrInvalidMetadata)
+}
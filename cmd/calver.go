@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
+)
+
+// now is the clock used for calendar versions, replaced in tests
+var now = time.Now
+
+const calverSchemePrefix = "calver:"
+
+var ErrCalverInFuture = fmt.Errorf("latest version is dated after the current date")
+
+// calverSegment is one dot separated part of a calendar version format
+type calverSegment struct {
+	token string
+	// counter is set for segments that are incremented rather than derived from the date
+	counter bool
+}
+
+// calverFormat is a parsed calendar version format such as YYYY.MM.MICRO or YY.0W.PATCH.
+// It always has three segments so that the result is still a valid semver version, with
+// the date segments first and one or more counters after them.
+// The zero padded tokens (0Y, 0M, 0W, 0D) are accepted, but rendered without padding
+// because semver does not allow leading zeros.
+type calverFormat []calverSegment
+
+func parseCalverFormat(format string) (calverFormat, error) {
+	parts := strings.Split(format, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("calver format %q must have three segments", format)
+	}
+
+	f := make(calverFormat, len(parts))
+	seenCounter := false
+	for i, p := range parts {
+		token := strings.ToUpper(p)
+		switch token {
+		case "YYYY", "YY", "0Y", "MM", "0M", "WW", "0W", "DD", "0D":
+			if seenCounter {
+				return nil, fmt.Errorf("calver format %q has a date segment after a counter", format)
+			}
+			f[i] = calverSegment{token: token}
+		case "MAJOR", "MINOR", "MICRO", "PATCH":
+			seenCounter = true
+			f[i] = calverSegment{token: token, counter: true}
+		default:
+			return nil, fmt.Errorf("unknown calver segment %q", p)
+		}
+	}
+	if !seenCounter {
+		return nil, fmt.Errorf("calver format %q needs a MAJOR, MINOR or MICRO counter", format)
+	}
+	return f, nil
+}
+
+func (f calverFormat) usesWeeks() bool {
+	for _, s := range f {
+		if s.token == "WW" || s.token == "0W" {
+			return true
+		}
+	}
+	return false
+}
+
+// dateValue returns the value of a date segment for t
+func (f calverFormat) dateValue(s calverSegment, t time.Time) uint64 {
+	year := t.Year()
+	// Week numbers are ISO weeks, so the year has to be the ISO year as well
+	// otherwise the last days of December can sort before the start of the year.
+	isoYear, week := t.ISOWeek()
+	if f.usesWeeks() {
+		year = isoYear
+	}
+
+	switch s.token {
+	case "YYYY":
+		return uint64(year)
+	case "YY", "0Y":
+		return uint64(year - 2000)
+	case "MM", "0M":
+		return uint64(t.Month())
+	case "WW", "0W":
+		return uint64(week)
+	case "DD", "0D":
+		return uint64(t.Day())
+	}
+	return 0
+}
+
+// counterFor returns the index of the counter segment that a bump type increments.
+// Bumps that have no matching counter increment the last counter in the format.
+func (f calverFormat) counterFor(bumpWhat BumpType) int {
+	want := map[BumpType][]string{
+		MajorBump: {"MAJOR"},
+		MinorBump: {"MINOR"},
+		PatchBump: {"MICRO", "PATCH"},
+	}[bumpWhat]
+	for i, s := range f {
+		for _, w := range want {
+			if s.token == w {
+				return i
+			}
+		}
+	}
+	return len(f) - 1
+}
+
+func versionSegments(v *semver.Version) [3]uint64 {
+	return [3]uint64{v.Major(), v.Minor(), v.Patch()}
+}
+
+// calverBump computes the next calendar version after version (which may be empty if
+// there is no previous version) for the date returned by now().
+// When the date segments have moved on, all counters reset to zero. Otherwise the counter
+// selected by bumpWhat is incremented and the counters after it are reset.
+func calverBump(version string, format string, bumpWhat BumpType) (*semver.Version, error) {
+	f, err := parseCalverFormat(format)
+	if err != nil {
+		return &semver.Version{}, err
+	}
+
+	var old *semver.Version
+	prefix := ""
+	if version != "" {
+		old, err = semver.NewVersion(version)
+		if err != nil {
+			return &semver.Version{}, err
+		}
+		if strings.HasPrefix(old.Original(), "v") {
+			prefix = "v"
+		}
+	}
+
+	t := now()
+	var segments [3]uint64
+	dateChanged := old == nil
+	for i, s := range f {
+		if s.counter {
+			continue
+		}
+		segments[i] = f.dateValue(s, t)
+		if old != nil && !dateChanged {
+			oldValue := versionSegments(old)[i]
+			if oldValue > segments[i] {
+				return &semver.Version{}, ErrCalverInFuture
+			}
+			dateChanged = oldValue < segments[i]
+		}
+	}
+
+	if !dateChanged {
+		oldSegments := versionSegments(old)
+		for i, s := range f {
+			if s.counter {
+				segments[i] = oldSegments[i]
+			}
+		}
+		switch {
+		case bumpWhat == PrereleaseBump && old.Prerelease() != "":
+			// Another prerelease of the same version
+			return doSemverBump(version, PrereleaseBump)
+		case old.Prerelease() != "":
+			// Releasing a prerelease keeps its counters, as semver does
+		default:
+			target := f.counterFor(bumpWhat)
+			segments[target]++
+			for i := target + 1; i < len(f); i++ {
+				if f[i].counter {
+					segments[i] = 0
+				}
+			}
+		}
+	}
+
+	newVersion := fmt.Sprintf("%s%d.%d.%d", prefix, segments[0], segments[1], segments[2])
+	if bumpWhat == PrereleaseBump {
+		newVersion += "-" + viper.GetString("prerelease-prefix") + ".1"
+	}
+	return semver.NewVersion(newVersion)
+}
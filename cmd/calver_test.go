@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func setClock(t *testing.T, date string) {
+	fixed, err := time.Parse("2006-01-02", date)
+	assert.NoError(t, err)
+	oldNow := now
+	now = func() time.Time { return fixed }
+	t.Cleanup(func() { now = oldNow })
+}
+
+func TestParseCalverFormatValid(t *testing.T) {
+	f, err := parseCalverFormat("YYYY.MM.MICRO")
+	assert.NoError(t, err)
+	assert.Equal(t, calverFormat{{token: "YYYY"}, {token: "MM"}, {token: "MICRO", counter: true}}, f)
+}
+
+func TestParseCalverFormatInvalid(t *testing.T) {
+	for _, format := range []string{"YYYY.MM", "YYYY.MM.DD", "YYYY.MICRO.MM", "YYYY.MM.BUILD", "YYYY.MM.DD.MICRO"} {
+		_, err := parseCalverFormat(format)
+		assert.Error(t, err, format)
+	}
+}
+
+func TestCalverBumpNoPreviousVersion(t *testing.T) {
+	viper.Reset()
+	setClock(t, "2025-03-14")
+	result, err := calverBump("", "YYYY.MM.MICRO", PatchBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025.3.0", result.Original())
+}
+
+func TestCalverBumpSameMonth(t *testing.T) {
+	viper.Reset()
+	setClock(t, "2025-03-14")
+	result, err := calverBump("v2025.3.4", "YYYY.MM.MICRO", PatchBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2025.3.5", result.Original())
+}
+
+func TestCalverBumpNewMonthResetsMicro(t *testing.T) {
+	viper.Reset()
+	setClock(t, "2025-04-01")
+	result, err := calverBump("v2025.3.4", "YYYY.MM.MICRO", PatchBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2025.4.0", result.Original())
+}
+
+func TestCalverBumpShortYearAndWeek(t *testing.T) {
+	viper.Reset()
+	setClock(t, "2025-02-05")
+	result, err := calverBump("25.6.2", "YY.0W.PATCH", PatchBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "25.6.3", result.Original())
+}
+
+func TestCalverBumpWeekUsesISOYear(t *testing.T) {
+	viper.Reset()
+	// 2024-12-30 is in ISO week 1 of 2025
+	setClock(t, "2024-12-30")
+	result, err := calverBump("24.52.3", "YY.WW.MICRO", PatchBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "25.1.0", result.Original())
+}
+
+func TestCalverBumpHybridMinorResetsMicro(t *testing.T) {
+	viper.Reset()
+	setClock(t, "2025-03-14")
+	result, err := calverBump("2025.2.7", "YYYY.MINOR.MICRO", MinorBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025.3.0", result.Original())
+}
+
+func TestCalverBumpMajorWithoutCounterUsesLast(t *testing.T) {
+	viper.Reset()
+	setClock(t, "2025-03-14")
+	result, err := calverBump("2025.3.1", "YYYY.MM.MICRO", MajorBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025.3.2", result.Original())
+}
+
+func TestCalverBumpPrerelease(t *testing.T) {
+	viper.Reset()
+	viper.Set("prerelease-prefix", "rc")
+	setClock(t, "2025-03-14")
+
+	result, err := calverBump("2025.3.1", "YYYY.MM.MICRO", PrereleaseBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025.3.2-rc.1", result.Original())
+
+	result, err = calverBump("2025.3.2-rc.1", "YYYY.MM.MICRO", PrereleaseBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025.3.2-rc.2", result.Original())
+
+	result, err = calverBump("2025.3.2-rc.2", "YYYY.MM.MICRO", PatchBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025.3.2", result.Original())
+}
+
+func TestCalverBumpPreviousInFuture(t *testing.T) {
+	viper.Reset()
+	setClock(t, "2025-03-14")
+	_, err := calverBump("2025.4.0", "YYYY.MM.MICRO", PatchBump)
+	assert.ErrorIs(t, err, ErrCalverInFuture)
+}
+
+func TestDoBumpWithCalverScheme(t *testing.T) {
+	viper.Reset()
+	viper.Set("scheme", "calver:YYYY.MM.MICRO")
+	setClock(t, "2025-03-14")
+	result, err := doBump("2025.3.0", PatchBump)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "2025.3.1", result.String())
+}
+
+func TestDoBumpUnknownScheme(t *testing.T) {
+	viper.Reset()
+	viper.Set("scheme", "romver")
+	_, err := doBump("1.0.0", PatchBump)
+	viper.Reset()
+	assert.Error(t, err)
+}
+
+func TestGitBumpWithCalverScheme(t *testing.T) {
+	viper.Reset()
+	viper.Set("scheme", "calver:YYYY.MM.MICRO")
+	setClock(t, "2025-03-14")
+
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v2025.2.3", commit, nil)
+	assert.NoError(t, err)
+	commit, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v2025.3.0", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file3.txt", repo)
+	assert.NoError(t, err)
+
+	result, err := gitBump(repo)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "v2025.3.1", result.Original())
+}
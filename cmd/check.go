@@ -0,0 +1,180 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	gosort "sort"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/cobra"
+)
+
+var checkRepoPath string
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check a repository's versioning for problems",
+}
+
+// checkHistoryCmd represents the check history subcommand
+var checkHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Find gaps and regressions in the tag history",
+	Long: `Walk the first-parent history from HEAD, oldest first, and check that the
+tagged versions only go up. The problems found are:
+
+	gap                       a release that skips a version, e.g. 1.3.0 followed by 1.5.0
+	regression                a version lower than one tagged on an earlier commit
+	prerelease-after-release  a prerelease tagged after its final release, e.g.
+	                          1.4.0-rc.2 after 1.4.0
+
+Exits with a non-zero status if any problems are found, so it can be used as a
+gate before releasing.`,
+	Run: runCheckHistory,
+}
+
+func init() {
+	checkHistoryCmd.Flags().StringVarP(&checkRepoPath, "repository", "r", ".", "Path to the git repository (defaults to current directory)")
+	checkCmd.AddCommand(checkHistoryCmd)
+}
+
+// firstParentVersions returns the versions tagged along the first-parent history of
+// HEAD, oldest first. Several tags on one commit are returned in semver order.
+func firstParentVersions(repo *goget.Repository) ([]*semver.Version, error) {
+	tagCommits, err := getTagCommits(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tags: %w", err)
+	}
+	headRef, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HEAD: %w", err)
+	}
+	commit, err := repo.CommitObject(headRef.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("error getting HEAD commit: %w", err)
+	}
+
+	// Collected newest first, then reversed
+	versions := make([]*semver.Version, 0)
+	for {
+		tags := append([]*semver.Version(nil), tagCommits[commit.Hash]...)
+		gosort.Slice(tags, func(i, j int) bool { return tags[j].LessThan(tags[i]) })
+		versions = append(versions, tags...)
+
+		if commit.NumParents() == 0 {
+			break
+		}
+		commit, err = commit.Parent(0)
+		if err == object.ErrParentNotFound {
+			// Shallow clone
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("error walking commit history: %w", err)
+		}
+	}
+	for i, j := 0, len(versions)-1; i < j; i, j = i+1, j-1 {
+		versions[i], versions[j] = versions[j], versions[i]
+	}
+	return versions, nil
+}
+
+// nextReleases are the releases that can follow a release without skipping a version
+func nextReleases(v *semver.Version) []semver.Version {
+	return []semver.Version{v.IncPatch(), v.IncMinor(), v.IncMajor()}
+}
+
+// checkVersionHistory checks a sequence of versions, oldest first
+func checkVersionHistory(versions []*semver.Version) []tagProblem {
+	problems := make([]tagProblem, 0)
+	var highest, lastRelease *semver.Version
+	released := make(map[string]*semver.Version)
+
+	for _, v := range versions {
+		core := fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+		switch {
+		case v.Prerelease() != "" && released[core] != nil:
+			problems = append(problems, tagProblem{
+				Kind:   "prerelease-after-release",
+				Tag:    v.Original(),
+				Detail: fmt.Sprintf("tagged after %s", released[core].Original()),
+			})
+		case highest != nil && v.LessThan(highest):
+			problems = append(problems, tagProblem{
+				Kind:   "regression",
+				Tag:    v.Original(),
+				Detail: fmt.Sprintf("tagged after %s", highest.Original()),
+			})
+		case v.Prerelease() == "" && lastRelease != nil && v.GreaterThan(lastRelease):
+			expected := nextReleases(lastRelease)
+			skipped := true
+			for _, next := range expected {
+				if v.Major() == next.Major() && v.Minor() == next.Minor() && v.Patch() == next.Patch() {
+					skipped = false
+				}
+			}
+			if skipped {
+				problems = append(problems, tagProblem{
+					Kind:   "gap",
+					Tag:    v.Original(),
+					Detail: fmt.Sprintf("follows %s, expected %s, %s or %s", lastRelease.Original(), expected[0].String(), expected[1].String(), expected[2].String()),
+				})
+			}
+		}
+
+		if highest == nil || v.GreaterThan(highest) {
+			highest = v
+		}
+		if v.Prerelease() == "" {
+			if released[core] == nil {
+				released[core] = v
+			}
+			if lastRelease == nil || v.GreaterThan(lastRelease) {
+				lastRelease = v
+			}
+		}
+	}
+	return problems
+}
+
+// checkHistory checks the tags along the first-parent history of HEAD
+func checkHistory(repo *goget.Repository) ([]tagProblem, error) {
+	versions, err := firstParentVersions(repo)
+	if err != nil {
+		return nil, err
+	}
+	return checkVersionHistory(versions), nil
+}
+
+func runCheckHistory(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Unexpected arguments: %v\n", args)
+		_ = cmd.Help()
+		os.Exit(1)
+	}
+
+	repo, err := goget.PlainOpenWithOptions(checkRepoPath, &goget.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open git repository: %s\n", err)
+		os.Exit(1)
+	}
+
+	problems, err := checkHistory(repo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking history: %s\n", err)
+		os.Exit(1)
+	}
+	if len(problems) == 0 {
+		fmt.Println("No problems found")
+		return
+	}
+	if err := writeTagProblems(os.Stdout, problems); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func problemKinds(problems []tagProblem) []string {
+	result := make([]string, len(problems))
+	for i, p := range problems {
+		result[i] = p.Kind + " " + p.Tag
+	}
+	return result
+}
+
+func TestCheckVersionHistory(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		expected []string
+	}{
+		{"clean", []string{"v1.0.0", "v1.0.1", "v1.1.0-rc.1", "v1.1.0", "v2.0.0"}, []string{}},
+		{"gap", []string{"v1.3.0", "v1.5.0"}, []string{"gap v1.5.0"}},
+		{"major gap", []string{"v1.3.0", "v2.1.0"}, []string{"gap v2.1.0"}},
+		{"regression", []string{"v1.3.0", "v1.2.1"}, []string{"regression v1.2.1"}},
+		{"prerelease after release", []string{"v1.4.0-rc.1", "v1.4.0", "v1.4.0-rc.2"}, []string{"prerelease-after-release v1.4.0-rc.2"}},
+		{"prerelease regression", []string{"v1.4.0-rc.2", "v1.4.0-rc.1"}, []string{"regression v1.4.0-rc.1"}},
+		{"prereleases don't gap", []string{"v1.3.0", "v1.5.0-rc.1"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := checkVersionHistory(mustParseAll(tt.versions...))
+			assert.Equal(t, tt.expected, problemKinds(problems))
+		})
+	}
+}
+
+func TestCheckHistoryFirstParent(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+
+	commit1, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.3.0", commit1, nil)
+	assert.NoError(t, err)
+	commit2, err := commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.5.0", commit2, nil)
+	assert.NoError(t, err)
+	commit3, err := commitFile("file3.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.4.1", commit3, nil)
+	assert.NoError(t, err)
+
+	versions, err := firstParentVersions(repo)
+	assert.NoError(t, err)
+	result := make([]string, len(versions))
+	for i, v := range versions {
+		result[i] = v.Original()
+	}
+	assert.Equal(t, []string{"v1.3.0", "v1.5.0", "v1.4.1"}, result)
+
+	problems, err := checkHistory(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gap v1.5.0", "regression v1.4.1"}, problemKinds(problems))
+}
+
+func TestCheckHistoryNoTags(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	_, err = commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+
+	problems, err := checkHistory(repo)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
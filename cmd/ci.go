@@ -0,0 +1,141 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
+)
+
+type CISystem string
+
+const (
+	GitHubActions  CISystem = "github"
+	GitLabCI       CISystem = "gitlab"
+	AzurePipelines CISystem = "azure"
+	NoCI           CISystem = "none"
+)
+
+// defaultGitLabDotenv is the file written on GitLab when SEMVERTOOL_DOTENV is not set.
+// It needs to be listed under artifacts:reports:dotenv in the job definition.
+const defaultGitLabDotenv = "semvertool.env"
+
+var ErrNoCISystem = fmt.Errorf("no supported CI system detected")
+
+// bumpResult describes the outcome of a bump, so it can be reported to other tools.
+type bumpResult struct {
+	Previous *semver.Version
+	Current  *semver.Version
+	BumpType BumpType
+}
+
+// detectCI works out which CI system we are running under from the environment
+// variables each of them sets for every job.
+func detectCI() CISystem {
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		return GitHubActions
+	}
+	if os.Getenv("GITLAB_CI") == "true" {
+		return GitLabCI
+	}
+	if strings.EqualFold(os.Getenv("TF_BUILD"), "true") {
+		return AzurePipelines
+	}
+	return NoCI
+}
+
+// printedVersion is the form of a version the bump commands print: without a "v"
+// prefix, except with --go, as Go module versions are not valid without it
+func printedVersion(v *semver.Version) string {
+	if viper.GetBool("go") {
+		return v.Original()
+	}
+	return v.String()
+}
+
+// ciOutputValues returns the name/value pairs reported to the CI system, in a stable
+// order. Versions are in the same form as the printed version.
+func ciOutputValues(r *bumpResult) [][2]string {
+	previous := ""
+	if r.Previous != nil {
+		previous = printedVersion(r.Previous)
+	}
+	return [][2]string{
+		{"version", printedVersion(r.Current)},
+		{"previous_version", previous},
+		{"bump_type", string(r.BumpType)},
+		{"major", fmt.Sprint(r.Current.Major())},
+		{"minor", fmt.Sprint(r.Current.Minor())},
+		{"patch", fmt.Sprint(r.Current.Patch())},
+		{"prerelease", r.Current.Prerelease()},
+	}
+}
+
+func appendToFile(path string, lines []string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(f, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ciOutputFile returns the file writeCIOutput appends to under the detected CI system,
+// or "" if it doesn't write one
+func ciOutputFile() string {
+	switch detectCI() {
+	case GitHubActions:
+		return os.Getenv("GITHUB_OUTPUT")
+	case GitLabCI:
+		if path := os.Getenv("SEMVERTOOL_DOTENV"); path != "" {
+			return path
+		}
+		return defaultGitLabDotenv
+	}
+	return ""
+}
+
+// writeCIOutput reports the bump result to the detected CI system.
+// GitHub Actions gets step outputs appended to $GITHUB_OUTPUT, GitLab gets a dotenv
+// artifact (SEMVERTOOL_DOTENV, defaulting to semvertool.env), and Azure Pipelines
+// gets ##vso logging commands written to out.
+func writeCIOutput(out io.Writer, r *bumpResult) error {
+	values := ciOutputValues(r)
+	lines := make([]string, 0, len(values))
+
+	switch detectCI() {
+	case GitHubActions:
+		path := ciOutputFile()
+		if path == "" {
+			return fmt.Errorf("GITHUB_OUTPUT is not set")
+		}
+		for _, kv := range values {
+			lines = append(lines, fmt.Sprintf("%s=%s", kv[0], kv[1]))
+		}
+		return appendToFile(path, lines)
+	case GitLabCI:
+		for _, kv := range values {
+			lines = append(lines, fmt.Sprintf("%s=%s", strings.ToUpper(kv[0]), kv[1]))
+		}
+		return appendToFile(ciOutputFile(), lines)
+	case AzurePipelines:
+		for _, kv := range values {
+			if _, err := fmt.Fprintf(out, "##vso[task.setvariable variable=%s;isOutput=true]%s\n", kv[0], kv[1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ErrNoCISystem
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func clearCIEnv(t *testing.T) {
+	viper.Reset()
+	t.Setenv("GITHUB_ACTIONS", "")
+	t.Setenv("GITLAB_CI", "")
+	t.Setenv("TF_BUILD", "")
+}
+
+func testBumpResult() *bumpResult {
+	return &bumpResult{
+		Previous: semver.MustParse("v1.2.3"),
+		Current:  semver.MustParse("v1.3.0-rc.1"),
+		BumpType: MinorBump,
+	}
+}
+
+func TestDetectCINone(t *testing.T) {
+	clearCIEnv(t)
+	assert.Equal(t, NoCI, detectCI())
+}
+
+func TestDetectCIAzure(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("TF_BUILD", "True")
+	assert.Equal(t, AzurePipelines, detectCI())
+}
+
+func TestWriteCIOutputGitHub(t *testing.T) {
+	clearCIEnv(t)
+	outFile := filepath.Join(t.TempDir(), "github_output")
+	assert.NoError(t, os.WriteFile(outFile, []byte("existing=value\n"), 0644))
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_OUTPUT", outFile)
+
+	err := writeCIOutput(&bytes.Buffer{}, testBumpResult())
+	assert.NoError(t, err)
+
+	contents, err := os.ReadFile(outFile)
+	assert.NoError(t, err)
+	expected := `existing=value
+version=1.3.0-rc.1
+previous_version=1.2.3
+bump_type=minor
+major=1
+minor=3
+patch=0
+prerelease=rc.1
+`
+	assert.Equal(t, expected, string(contents))
+}
+
+func TestWriteCIOutputGitHubNoOutputFile(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_OUTPUT", "")
+
+	err := writeCIOutput(&bytes.Buffer{}, testBumpResult())
+	assert.Error(t, err)
+}
+
+func TestWriteCIOutputGitLab(t *testing.T) {
+	clearCIEnv(t)
+	outFile := filepath.Join(t.TempDir(), "build.env")
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("SEMVERTOOL_DOTENV", outFile)
+
+	err := writeCIOutput(&bytes.Buffer{}, testBumpResult())
+	assert.NoError(t, err)
+
+	contents, err := os.ReadFile(outFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(contents), "VERSION=1.3.0-rc.1\n")
+	assert.Contains(t, string(contents), "PREVIOUS_VERSION=1.2.3\n")
+	assert.Contains(t, string(contents), "BUMP_TYPE=minor\n")
+}
+
+func TestWriteCIOutputAzure(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("TF_BUILD", "True")
+
+	var buf bytes.Buffer
+	err := writeCIOutput(&buf, testBumpResult())
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "##vso[task.setvariable variable=version;isOutput=true]1.3.0-rc.1\n")
+	assert.Contains(t, buf.String(), "##vso[task.setvariable variable=major;isOutput=true]1\n")
+}
+
+func TestCIOutputValuesGo(t *testing.T) {
+	clearCIEnv(t)
+	defer viper.Reset()
+
+	values := ciOutputValues(testBumpResult())
+	assert.Equal(t, [2]string{"version", "1.3.0-rc.1"}, values[0])
+
+	viper.Set("go", true)
+	values = ciOutputValues(testBumpResult())
+	assert.Equal(t, [2]string{"version", "v1.3.0-rc.1"}, values[0])
+	assert.Equal(t, [2]string{"previous_version", "v1.2.3"}, values[1])
+}
+
+func TestWriteCIOutputNoCI(t *testing.T) {
+	clearCIEnv(t)
+
+	err := writeCIOutput(&bytes.Buffer{}, testBumpResult())
+	assert.ErrorIs(t, err, ErrNoCISystem)
+}
+
+func TestGitBumpWithResult(t *testing.T) {
+	viper.Reset()
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
+	result, err := gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", result.Previous.Original())
+	assert.Equal(t, "v1.0.1", result.Current.Original())
+	assert.Equal(t, PatchBump, result.BumpType)
+}
+
+func TestWriteCIOutputMatchesPlan(t *testing.T) {
+	clearCIEnv(t)
+	defer viper.Reset()
+	outFile := filepath.Join(t.TempDir(), "build.env")
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("SEMVERTOOL_DOTENV", outFile)
+	viper.Set("ci-output", true)
+
+	plan, err := planRelease(nil, testBumpResult())
+	assert.NoError(t, err)
+	assert.NoError(t, writeCIOutput(&bytes.Buffer{}, testBumpResult()))
+	assert.Equal(t, []string{outFile}, plan.Files)
+	assert.FileExists(t, plan.Files[0])
+}
@@ -0,0 +1,64 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/spf13/viper"
+)
+
+const defaultConfigFile = ".semvertool.yaml"
+
+// cfgFile is the config file given with --config
+var cfgFile string
+
+// config is the contents of the config file
+type config struct {
+	Policy bumpPolicy `mapstructure:"policy"`
+	// Markers are checked before the default commit message markers
+	Markers []markerPattern `mapstructure:"markers"`
+	// Rules select the bump type for bump git --from-commit
+	Rules []bumpRule `mapstructure:"rules"`
+}
+
+// loadConfig reads the config file at path, or .semvertool.yaml in the current
+// directory if path is empty. A missing default config file isn't an error, and gives
+// the default config.
+func loadConfig(path string) (*config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigFile
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetDefault("policy.allow-zero-major", true)
+	v.SetDefault("policy.allow-prerelease-on-main", true)
+	v.SetDefault("policy.main-branches", []string{"main", "master"})
+	if err := v.ReadInConfig(); err != nil && (explicit || !errors.Is(err, fs.ErrNotExist)) {
+		return nil, fmt.Errorf("could not read config file %s: %w", path, err)
+	}
+
+	c := &config{}
+	if err := v.Unmarshal(c); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if err := c.Policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	for _, m := range c.Markers {
+		if err := m.validate(); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+	for _, r := range c.Rules {
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/cobra"
+)
+
+const defaultDescribeTemplate = "{{.DevVersion}}+g{{.ShortSHA}}{{if .Dirty}}.dirty{{end}}"
+
+var (
+	describeRepoPath string
+	describeTemplate string
+	describeLabel    string
+)
+
+// describeCmd represents the describe command
+var describeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Describe HEAD as a development version relative to the nearest semver tag",
+	Long: `Describe HEAD as a unique, sortable development version, like git describe.
+
+The nearest semver tag reachable from HEAD, the one with the fewest commits
+since it as git describe picks, is used as the base, and the number of commits
+since it is added as a prerelease, so that later commits sort higher:
+
+	1.4.1-dev.7+g3f6d127         7 commits after v1.4.0
+	1.5.0-rc.1.dev.2+g3f6d127    2 commits after v1.5.0-rc.1
+	1.4.1-dev.7+g3f6d127.dirty   with uncommitted changes
+
+If HEAD is exactly at a tag and the worktree is clean, the tag's version is
+printed as it is.
+
+The output can be changed with --template, a Go template with these fields:
+
+	.Tag         the base tag as written, e.g. v1.4.0
+	.Base        the base version without a "v" prefix, e.g. 1.4.0
+	.Next        the version the next release will have, e.g. 1.4.1
+	.DevVersion  .Next with the label and distance added, e.g. 1.4.1-dev.7
+	.Distance    the number of commits since the base tag
+	.SHA         the full commit hash of HEAD
+	.ShortSHA    the first 7 characters of the commit hash
+	.Dirty       true if the worktree has uncommitted changes`,
+	Run: runDescribe,
+}
+
+func init() {
+	describeCmd.Flags().StringVarP(&describeRepoPath, "repository", "r", ".", "Path to the git repository (defaults to current directory)")
+	describeCmd.Flags().StringVar(&describeTemplate, "template", defaultDescribeTemplate, "Go template used to render the version")
+	describeCmd.Flags().StringVar(&describeLabel, "label", "dev", "Prerelease label put before the commit count")
+}
+
+// describeValues are the fields available to describe templates
+type describeValues struct {
+	Tag        string
+	Base       string
+	Next       string
+	DevVersion string
+	Distance   int
+	SHA        string
+	ShortSHA   string
+	Dirty      bool
+}
+
+// nearestTag returns the semver tag with the fewest commits between it and a commit,
+// as git describe does, along with the commit it points at. Ties, including several
+// tags on one commit, go to the highest version.
+func nearestTag(repo *goget.Repository, from plumbing.Hash) (*semver.Version, plumbing.Hash, error) {
+	tagCommits, err := getTagCommits(repo)
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("error getting tags: %w", err)
+	}
+
+	// Find the tagged commits reachable from "from" without passing another tagged
+	// commit. Tags behind those are always further away, so they are skipped.
+	var candidates []plumbing.Hash
+	seen := map[plumbing.Hash]bool{from: true}
+	pending := []plumbing.Hash{from}
+	for len(pending) > 0 {
+		hash := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if len(tagCommits[hash]) > 0 {
+			candidates = append(candidates, hash)
+			continue
+		}
+		c, err := repo.CommitObject(hash)
+		if err != nil {
+			return nil, plumbing.ZeroHash, fmt.Errorf("error walking commit history: %w", err)
+		}
+		for _, parent := range c.ParentHashes {
+			if !seen[parent] {
+				seen[parent] = true
+				pending = append(pending, parent)
+			}
+		}
+	}
+
+	var nearest *semver.Version
+	var nearestCommit plumbing.Hash
+	nearestDistance := 0
+	for _, hash := range candidates {
+		distance, err := commitsSince(repo, from, hash)
+		if err != nil {
+			return nil, plumbing.ZeroHash, err
+		}
+		for _, v := range tagCommits[hash] {
+			if nearest == nil || distance < nearestDistance || (distance == nearestDistance && v.GreaterThan(nearest)) {
+				nearest = v
+				nearestCommit = hash
+				nearestDistance = distance
+			}
+		}
+	}
+	if nearest == nil {
+		return nil, plumbing.ZeroHash, ErrNoSemverTags
+	}
+	return nearest, nearestCommit, nil
+}
+
+// commitsSince counts the commits reachable from "from" that are not reachable from
+// "base", the same as git rev-list --count base..from
+func commitsSince(repo *goget.Repository, from, base plumbing.Hash) (int, error) {
+	seen := make(map[plumbing.Hash]bool)
+	baseIter, err := repo.Log(&goget.LogOptions{From: base})
+	if err != nil {
+		return 0, fmt.Errorf("error getting commit history: %w", err)
+	}
+	defer baseIter.Close()
+	if err := baseIter.ForEach(func(c *object.Commit) error {
+		seen[c.Hash] = true
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("error walking commit history: %w", err)
+	}
+
+	count := 0
+	fromIter, err := repo.Log(&goget.LogOptions{From: from})
+	if err != nil {
+		return 0, fmt.Errorf("error getting commit history: %w", err)
+	}
+	defer fromIter.Close()
+	if err := fromIter.ForEach(func(c *object.Commit) error {
+		if !seen[c.Hash] {
+			count++
+		}
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("error walking commit history: %w", err)
+	}
+	return count, nil
+}
+
+// isDirty reports whether the worktree has uncommitted changes
+func isDirty(repo *goget.Repository) (bool, error) {
+	w, err := repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+	status, err := w.Status()
+	if err != nil {
+		return false, err
+	}
+	return !status.IsClean(), nil
+}
+
+// describeHead collects the values describing HEAD relative to its nearest tag
+func describeHead(repo *goget.Repository, label string) (*describeValues, error) {
+	headRef, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HEAD: %w", err)
+	}
+	head := headRef.Hash()
+
+	tag, tagCommit, err := nearestTag(repo, head)
+	if err != nil {
+		return nil, err
+	}
+	distance, err := commitsSince(repo, head, tagCommit)
+	if err != nil {
+		return nil, err
+	}
+	dirty, err := isDirty(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error getting worktree status: %w", err)
+	}
+
+	base, err := tag.SetMetadata("")
+	if err != nil {
+		return nil, err
+	}
+	next := base
+	devVersion := ""
+	if base.Prerelease() == "" {
+		next = base.IncPatch()
+		devVersion = fmt.Sprintf("%s-%s.%d", next.String(), label, distance)
+	} else {
+		devVersion = fmt.Sprintf("%s.%s.%d", next.String(), label, distance)
+	}
+
+	return &describeValues{
+		Tag:        tag.Original(),
+		Base:       base.String(),
+		Next:       next.String(),
+		DevVersion: devVersion,
+		Distance:   distance,
+		SHA:        head.String(),
+		ShortSHA:   head.String()[:7],
+		Dirty:      dirty,
+	}, nil
+}
+
+// renderDescribe renders the describe values through a template, checking that the
+// result is still a valid semver version
+func renderDescribe(values *describeValues, format string) (string, error) {
+	if values.Distance == 0 && !values.Dirty {
+		return values.Base, nil
+	}
+
+	tmpl, err := template.New("describe").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, values); err != nil {
+		return "", fmt.Errorf("could not render template: %w", err)
+	}
+	if _, err := semver.NewVersion(b.String()); err != nil {
+		return "", fmt.Errorf("template produced an invalid version %q: %w", b.String(), err)
+	}
+	return b.String(), nil
+}
+
+func runDescribe(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Unexpected arguments: %v\n", args)
+		_ = cmd.Help()
+		os.Exit(1)
+	}
+
+	repo, err := goget.PlainOpenWithOptions(describeRepoPath, &goget.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open git repository: %s\n", err)
+		os.Exit(1)
+	}
+
+	values, err := describeHead(repo, describeLabel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error describing HEAD: %s\n", err)
+		os.Exit(1)
+	}
+	version, err := renderDescribe(values, describeTemplate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error describing HEAD: %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(version)
+}
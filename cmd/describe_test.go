@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeHeadAtTag(t *testing.T) {
+	repo := setupRepoWithTags(t)
+
+	values, err := describeHead(repo, "dev")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, values.Distance)
+	assert.False(t, values.Dirty)
+
+	result, err := renderDescribe(values, defaultDescribeTemplate)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.0", result)
+}
+
+func TestDescribeCommitsAfterTag(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	_, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+	head, err := commitFile("file6.txt", repo)
+	assert.NoError(t, err)
+
+	values, err := describeHead(repo, "dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.0", values.Tag)
+	assert.Equal(t, "1.2.1", values.Next)
+	assert.Equal(t, 2, values.Distance)
+
+	result, err := renderDescribe(values, defaultDescribeTemplate)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.1-dev.2+g"+head.String()[:7], result)
+}
+
+func TestDescribeAfterPrerelease(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.5.0-rc.1", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
+	values, err := describeHead(repo, "dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5.0-rc.1.dev.1", values.DevVersion)
+}
+
+func TestDescribeDirtyWorktree(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	w, err := repo.Worktree()
+	assert.NoError(t, err)
+	_, err = w.Filesystem.Create("untracked.txt")
+	assert.NoError(t, err)
+
+	values, err := describeHead(repo, "dev")
+	assert.NoError(t, err)
+	assert.True(t, values.Dirty)
+
+	result, err := renderDescribe(values, defaultDescribeTemplate)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^1\.2\.1-dev\.0\+g[0-9a-f]{7}\.dirty$`, result)
+}
+
+func TestDescribeCustomTemplate(t *testing.T) {
+	values := &describeValues{Tag: "v1.4.0", Base: "1.4.0", Next: "1.4.1", Distance: 7, ShortSHA: "3f6d127"}
+
+	result, err := renderDescribe(values, "{{.Next}}-SNAPSHOT.{{.Distance}}")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.1-SNAPSHOT.7", result)
+}
+
+func TestDescribeTemplateInvalidVersion(t *testing.T) {
+	values := &describeValues{Tag: "v1.4.0", Base: "1.4.0", Next: "1.4.1", Distance: 7, ShortSHA: "3f6d127"}
+
+	_, err := renderDescribe(values, "build {{.Distance}}")
+	assert.Error(t, err)
+}
+
+func TestDescribeNoTags(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	_, err = commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+
+	_, err = describeHead(repo, "dev")
+	assert.ErrorIs(t, err, ErrNoSemverTags)
+}
+
+func TestCommitsSince(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	base, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	head, err := commitFile("file3.txt", repo)
+	assert.NoError(t, err)
+
+	count, err := commitsSince(repo, head, base)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestDescribeAfterMergingTaggedBranch(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	base, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.4.0", base, nil)
+	assert.NoError(t, err)
+	branch, err := commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.5.0", branch, nil)
+	assert.NoError(t, err)
+
+	// A commit on main next to the branch, then the merge, so the first parent of the
+	// merge leads back to v1.4.0
+	w, err := repo.Worktree()
+	assert.NoError(t, err)
+	main, err := w.Commit("Work on main", &git.CommitOptions{Parents: []plumbing.Hash{base}})
+	assert.NoError(t, err)
+	_, err = w.Commit("Merge the branch", &git.CommitOptions{Parents: []plumbing.Hash{main, branch}})
+	assert.NoError(t, err)
+	_, err = commitFile("file3.txt", repo)
+	assert.NoError(t, err)
+	head, err := commitFile("file4.txt", repo)
+	assert.NoError(t, err)
+
+	values, err := describeHead(repo, "dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.5.0", values.Tag)
+	assert.Equal(t, 4, values.Distance)
+
+	result, err := renderDescribe(values, defaultDescribeTemplate)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5.1-dev.4+g"+head.String()[:7], result)
+}
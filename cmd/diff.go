@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffExitCode bool
+	diffGit      bool
+	diffRepoPath string
+)
+
+// MetadataDiff is the difference between versions that only differ in their metadata
+const MetadataDiff BumpType = "metadata"
+
+// diffExitCodes are the exit codes of diff --exit-code. They don't overlap with the
+// exit codes of script compare.
+var diffExitCodes = map[BumpType]int{
+	NoBump:         0,
+	MajorBump:      21,
+	MinorBump:      22,
+	PatchBump:      23,
+	PrereleaseBump: 24,
+	MetadataDiff:   25,
+}
+
+// DiffVersions returns the most significant component that differs between two
+// versions: major, minor, patch, prerelease, metadata, or none if they are the same
+func DiffVersions(v1string, v2string string) (BumpType, error) {
+	v1, err := semver.NewVersion(v1string)
+	if err != nil {
+		return UnknownBump, fmt.Errorf("invalid version: %s", v1string)
+	}
+	v2, err := semver.NewVersion(v2string)
+	if err != nil {
+		return UnknownBump, fmt.Errorf("invalid version: %s", v2string)
+	}
+
+	diff := bumpTypeBetween(v1, v2)
+	if diff == NoBump && v1.Metadata() != v2.Metadata() {
+		return MetadataDiff, nil
+	}
+	return diff, nil
+}
+
+// tagsBetween returns the semver tags after the lower of two versions, up to and
+// including the higher one, in version order
+func tagsBetween(repo *goget.Repository, v1string, v2string string) ([]*semver.Version, error) {
+	lower, err := semver.NewVersion(v1string)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version: %s", v1string)
+	}
+	upper, err := semver.NewVersion(v2string)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version: %s", v2string)
+	}
+	if upper.LessThan(lower) {
+		lower, upper = upper, lower
+	}
+
+	tags, err := getTags(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tags: %w", err)
+	}
+	between := []*semver.Version{}
+	for _, t := range tags {
+		if t.GreaterThan(lower) && !t.GreaterThan(upper) {
+			between = append(between, t)
+		}
+	}
+	return between, nil
+}
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff <version1> <version2>",
+	Short: "Show the most significant difference between two versions",
+	Long: `Show the most significant component that differs between two versions:
+major, minor, patch, prerelease, metadata, or none if they are the same.
+The order of the versions doesn't matter.
+
+With --exit-code, the difference is also returned as the exit code:
+
+ 0: none
+ 21: major
+ 22: minor
+ 23: patch
+ 24: prerelease
+ 25: metadata
+
+If there is an error, the command will return 1.
+
+With --git, the tags after the lower version, up to and including the higher
+version, are listed after the difference.`,
+	Example: `  semvertool diff 1.2.3 2.0.0-rc.1
+  semvertool diff --exit-code 1.2.3 1.2.4 || echo "upgrade needed"
+  semvertool diff --git v1.2.0 v1.4.0`,
+	Args: cobra.ExactArgs(2),
+	Run:  runDiff,
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "Return the difference as the exit code")
+	diffCmd.Flags().BoolVar(&diffGit, "git", false, "List the tags between the two versions")
+	diffCmd.Flags().StringVarP(&diffRepoPath, "repository", "r", ".", "Path to the git repository for --git (defaults to current directory)")
+}
+
+func runDiff(cmd *cobra.Command, args []string) {
+	diff, err := DiffVersions(args[0], args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(diff)
+
+	if diffGit {
+		repo, err := goget.PlainOpenWithOptions(diffRepoPath, &goget.PlainOpenOptions{DetectDotGit: true})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open git repository: %s\n", err)
+			os.Exit(1)
+		}
+		tags, err := tagsBetween(repo, args[0], args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+		}
+		for _, t := range tags {
+			fmt.Println(t.Original())
+		}
+	}
+
+	if diffExitCode {
+		os.Exit(diffExitCodes[diff])
+	}
+}
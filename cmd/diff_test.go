@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffVersions(t *testing.T) {
+	tests := []struct {
+		v1, v2   string
+		expected BumpType
+	}{
+		{"1.2.3", "2.0.0-rc.1", MajorBump},
+		{"2.0.0", "1.9.9", MajorBump},
+		{"1.2.3", "1.3.0", MinorBump},
+		{"1.2.3", "v1.2.4", PatchBump},
+		{"1.2.3-rc.1", "1.2.3", PrereleaseBump},
+		{"1.2.3-rc.1", "1.2.3-rc.2", PrereleaseBump},
+		{"1.2.3+build.1", "1.2.3+build.2", MetadataDiff},
+		{"1.2.3", "v1.2.3", NoBump},
+	}
+	for _, tt := range tests {
+		diff, err := DiffVersions(tt.v1, tt.v2)
+		assert.NoError(t, err, "%s %s", tt.v1, tt.v2)
+		assert.Equal(t, tt.expected, diff, "%s %s", tt.v1, tt.v2)
+	}
+
+	_, err := DiffVersions("invalid", "1.0.0")
+	assert.Error(t, err)
+	_, err = DiffVersions("1.0.0", "invalid")
+	assert.Error(t, err)
+}
+
+func TestDiffExitCodes(t *testing.T) {
+	codes := map[int]bool{}
+	for _, code := range diffExitCodes {
+		assert.False(t, codes[code], "duplicate exit code %d", code)
+		codes[code] = true
+	}
+	// Exit codes of script compare, and 1 for errors
+	for _, code := range []int{1, 11, 12} {
+		assert.False(t, codes[code], "exit code %d overlaps", code)
+	}
+}
+
+func TestTagsBetween(t *testing.T) {
+	repo := setupRepoWithLines(t)
+
+	tags, err := tagsBetween(repo, "v1.1.0", "3.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v2.0.0", "v2.1.0", "v3.0.0"}, VersionsToStrings(tags))
+
+	tags, err = tagsBetween(repo, "2.1.0", "1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.1.0", "v2.0.0", "v2.1.0"}, VersionsToStrings(tags))
+
+	tags, err = tagsBetween(repo, "2.1.0", "2.1.0")
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	_, err = tagsBetween(repo, "invalid", "1.0.0")
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dockerTagsGit       bool
+	dockerTagsStdin     bool
+	dockerTagsLatest    bool
+	dockerTagsSeparator string
+)
+
+// dockerTagsCmd represents the docker-tags command
+var dockerTagsCmd = &cobra.Command{
+	Use:   "docker-tags <version>",
+	Short: "List the Docker/OCI image tags a version should be published as",
+	Long: `List the Docker/OCI image tags a version should be published as.
+
+A release version always gets its exact tag (without any "v" prefix or build
+metadata). It also moves the floating MAJOR.MINOR, MAJOR and latest tags, but
+only if no higher release already exists in that line, so 1.2 is not moved
+back to 1.2.3 when 1.2.4 has been released. Prereleases only get their exact tag.
+
+The existing versions are read from git tags with --git, or as whitespace
+separated versions on standard input with --stdin. One of them is required.
+
+Examples:
+	semvertool docker-tags --git 1.2.3
+	1.2.3 1.2 1 latest
+
+	git tag v1.3.0
+	semvertool docker-tags --git 1.2.4
+	1.2.4 1.2
+
+	semvertool docker-tags --git 2.0.0-rc.1
+	2.0.0-rc.1`,
+	Args: cobra.ExactArgs(1),
+	RunE: RunDockerTags,
+}
+
+func init() {
+	dockerTagsCmd.Flags().BoolVar(&dockerTagsGit, "git", false, "Read the existing versions from git tags")
+	dockerTagsCmd.Flags().BoolVar(&dockerTagsStdin, "stdin", false, "Read the existing versions from standard input")
+	dockerTagsCmd.Flags().BoolVar(&dockerTagsLatest, "latest", true, "Include the latest tag when the version is the highest release")
+	dockerTagsCmd.Flags().StringVar(&dockerTagsSeparator, "separator", " ", "Separator between the tags in the output")
+	dockerTagsCmd.MarkFlagsMutuallyExclusive("git", "stdin")
+	// Without the existing versions, floating tags would be moved back to older releases
+	dockerTagsCmd.MarkFlagsOneRequired("git", "stdin")
+}
+
+// DockerTags returns the image tags that version should be published as, given
+// the versions that already exist. Prerelease versions in existing are ignored,
+// as they never own a floating tag.
+func DockerTags(version *semver.Version, existing []*semver.Version, includeLatest bool) []string {
+	exact := strings.TrimPrefix(version.Original(), "v")
+	if version.Metadata() != "" {
+		exact = strings.TrimSuffix(exact, "+"+version.Metadata())
+	}
+	tags := []string{exact}
+
+	if version.Prerelease() != "" {
+		return tags
+	}
+
+	highestMinor, highestMajor, highest := true, true, true
+	for _, e := range FilterPrerelease(existing) {
+		if !e.GreaterThan(version) {
+			continue
+		}
+		highest = false
+		if e.Major() == version.Major() {
+			highestMajor = false
+			if e.Minor() == version.Minor() {
+				highestMinor = false
+			}
+		}
+	}
+
+	if highestMinor {
+		tags = append(tags, fmt.Sprintf("%d.%d", version.Major(), version.Minor()))
+	}
+	if highestMajor {
+		tags = append(tags, fmt.Sprint(version.Major()))
+	}
+	if highest && includeLatest {
+		tags = append(tags, "latest")
+	}
+	return tags
+}
+
+// readVersions parses whitespace separated versions from r
+func readVersions(r io.Reader) ([]*semver.Version, error) {
+	var versions []*semver.Version
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		v, err := semver.NewVersion(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("invalid semver version: %s", scanner.Text())
+		}
+		versions = append(versions, v)
+	}
+	return versions, scanner.Err()
+}
+
+func RunDockerTags(cmd *cobra.Command, args []string) error {
+	version, err := semver.NewVersion(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid semver version: %s", args[0])
+	}
+
+	var existing []*semver.Version
+	if dockerTagsGit {
+		repo, err := goget.PlainOpenWithOptions(".", &goget.PlainOpenOptions{DetectDotGit: true})
+		if err != nil {
+			return err
+		}
+		existing, err = getTags(repo)
+		if err != nil {
+			return err
+		}
+	} else if dockerTagsStdin {
+		existing, err = readVersions(os.Stdin)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(strings.Join(DockerTags(version, existing, dockerTagsLatest), dockerTagsSeparator))
+	return nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseAll(versions ...string) []*semver.Version {
+	result := make([]*semver.Version, len(versions))
+	for i, v := range versions {
+		result[i] = semver.MustParse(v)
+	}
+	return result
+}
+
+func TestDockerTagsHighestRelease(t *testing.T) {
+	existing := mustParseAll("v1.0.0", "v1.2.2", "v1.2.3")
+	result := DockerTags(semver.MustParse("v1.2.3"), existing, true)
+	assert.Equal(t, []string{"1.2.3", "1.2", "1", "latest"}, result)
+}
+
+func TestDockerTagsNoExistingVersions(t *testing.T) {
+	result := DockerTags(semver.MustParse("0.1.0"), nil, true)
+	assert.Equal(t, []string{"0.1.0", "0.1", "0", "latest"}, result)
+}
+
+func TestDockerTagsNewerPatchExists(t *testing.T) {
+	existing := mustParseAll("1.2.3", "1.2.4")
+	result := DockerTags(semver.MustParse("1.2.3"), existing, true)
+	assert.Equal(t, []string{"1.2.3"}, result)
+}
+
+func TestDockerTagsNewerMinorExists(t *testing.T) {
+	existing := mustParseAll("1.2.3", "1.3.0")
+	result := DockerTags(semver.MustParse("1.2.4"), existing, true)
+	assert.Equal(t, []string{"1.2.4", "1.2"}, result)
+}
+
+func TestDockerTagsNewerMajorExists(t *testing.T) {
+	existing := mustParseAll("1.2.3", "2.0.0")
+	result := DockerTags(semver.MustParse("1.3.0"), existing, true)
+	assert.Equal(t, []string{"1.3.0", "1.3", "1"}, result)
+}
+
+func TestDockerTagsIgnoresNewerPrerelease(t *testing.T) {
+	existing := mustParseAll("1.2.3", "2.0.0-rc.1")
+	result := DockerTags(semver.MustParse("1.2.4"), existing, true)
+	assert.Equal(t, []string{"1.2.4", "1.2", "1", "latest"}, result)
+}
+
+func TestDockerTagsPrerelease(t *testing.T) {
+	result := DockerTags(semver.MustParse("v2.0.0-rc.1+build.7"), nil, true)
+	assert.Equal(t, []string{"2.0.0-rc.1"}, result)
+}
+
+func TestDockerTagsStripsMetadata(t *testing.T) {
+	result := DockerTags(semver.MustParse("1.0.0+build.7"), nil, false)
+	assert.Equal(t, []string{"1.0.0", "1.0", "1"}, result)
+}
+
+func TestReadVersions(t *testing.T) {
+	result, err := readVersions(strings.NewReader("1.0.0 v1.1.0\n2.0.0-rc.1\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.0.0", "v1.1.0", "2.0.0-rc.1"}, VersionsToStrings(result))
+}
+
+func TestReadVersionsInvalid(t *testing.T) {
+	_, err := readVersions(strings.NewReader("1.0.0 foo"))
+	assert.Error(t, err)
+}
+
+func TestDockerTagsCmdRequiresSource(t *testing.T) {
+	assert.NoError(t, dockerTagsCmd.ParseFlags([]string{}))
+	assert.Error(t, dockerTagsCmd.ValidateFlagGroups())
+
+	assert.NoError(t, dockerTagsCmd.ParseFlags([]string{"--stdin"}))
+	assert.NoError(t, dockerTagsCmd.ValidateFlagGroups())
+	_ = dockerTagsCmd.Flags().Set("stdin", "false")
+}
@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+)
+
+var (
+	envShell  string
+	envPrefix string
+	envGit    bool
+)
+
+// envCmd represents the env command
+var envCmd = &cobra.Command{
+	Use:   "env [version]",
+	Short: "Print the components of a version as shell variables",
+	Long: `Print the components of a semver version as shell variable assignments.
+
+The version can be given on the command line, or taken from the latest semver
+tag in the git repository with --git.
+
+Examples:
+	semvertool env 1.2.3-rc.1+build.5
+	export SEMVER_VERSION=1.2.3-rc.1+build.5
+	export SEMVER_MAJOR=1
+	export SEMVER_MINOR=2
+	export SEMVER_PATCH=3
+	export SEMVER_PRERELEASE=rc.1
+	export SEMVER_METADATA=build.5
+
+	eval "$(semvertool env --git)"
+
+	semvertool env --shell fish --prefix APP_ 1.2.3
+	set -gx APP_VERSION "1.2.3"
+	...`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: RunEnv,
+}
+
+func init() {
+	envCmd.Flags().StringVar(&envShell, "shell", "sh", "Output syntax: sh, fish, powershell, or dotenv")
+	envCmd.Flags().StringVar(&envPrefix, "prefix", "SEMVER_", "Prefix for the variable names")
+	envCmd.Flags().BoolVar(&envGit, "git", false, "Use the latest semver tag from git instead of a command-line argument")
+}
+
+// versionComponents returns the named parts of a version, in a stable order.
+func versionComponents(v *semver.Version) [][2]string {
+	return [][2]string{
+		{"VERSION", v.Original()},
+		{"MAJOR", fmt.Sprint(v.Major())},
+		{"MINOR", fmt.Sprint(v.Minor())},
+		{"PATCH", fmt.Sprint(v.Patch())},
+		{"PRERELEASE", v.Prerelease()},
+		{"METADATA", v.Metadata()},
+	}
+}
+
+// formatEnv renders the components of v as variable assignments for the given shell.
+func formatEnv(v *semver.Version, prefix, shell string) (string, error) {
+	var format string
+	switch strings.ToLower(shell) {
+	case "sh", "bash", "zsh":
+		format = "export %s=%s\n"
+	case "fish":
+		format = "set -gx %s \"%s\"\n"
+	case "powershell", "pwsh":
+		format = "$env:%s = \"%s\"\n"
+	case "dotenv":
+		format = "%s=%s\n"
+	default:
+		return "", fmt.Errorf("unknown shell: %s", shell)
+	}
+
+	var b strings.Builder
+	for _, kv := range versionComponents(v) {
+		fmt.Fprintf(&b, format, prefix+kv[0], kv[1])
+	}
+	return b.String(), nil
+}
+
+// latestTag returns the highest semver tag in the repository.
+func latestTag(repo *goget.Repository) (*semver.Version, error) {
+	tags, err := getTags(repo)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, ErrNoSemverTags
+	}
+	return tags[len(tags)-1], nil
+}
+
+func RunEnv(cmd *cobra.Command, args []string) error {
+	var v *semver.Version
+
+	if envGit {
+		if len(args) != 0 {
+			return fmt.Errorf("unexpected arguments with --git: %v", args)
+		}
+		repo, err := goget.PlainOpenWithOptions(".", &goget.PlainOpenOptions{DetectDotGit: true})
+		if err != nil {
+			return err
+		}
+		v, err = latestTag(repo)
+		if err != nil {
+			return err
+		}
+	} else {
+		if len(args) != 1 {
+			return fmt.Errorf("a version is required unless --git is given")
+		}
+		var err error
+		v, err = semver.NewVersion(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid semver version: %s", args[0])
+		}
+	}
+
+	out, err := formatEnv(v, envPrefix, envShell)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
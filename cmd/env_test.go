@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatEnvSh(t *testing.T) {
+	v := semver.MustParse("1.2.3-rc.1+build.5")
+	expected := `export SEMVER_VERSION=1.2.3-rc.1+build.5
+export SEMVER_MAJOR=1
+export SEMVER_MINOR=2
+export SEMVER_PATCH=3
+export SEMVER_PRERELEASE=rc.1
+export SEMVER_METADATA=build.5
+`
+	result, err := formatEnv(v, "SEMVER_", "sh")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestFormatEnvFishWithPrefix(t *testing.T) {
+	v := semver.MustParse("v2.0.0")
+	result, err := formatEnv(v, "APP_", "fish")
+	assert.NoError(t, err)
+	assert.Contains(t, result, "set -gx APP_VERSION \"v2.0.0\"\n")
+	assert.Contains(t, result, "set -gx APP_PRERELEASE \"\"\n")
+}
+
+func TestFormatEnvPowershell(t *testing.T) {
+	v := semver.MustParse("2.0.0")
+	result, err := formatEnv(v, "SEMVER_", "powershell")
+	assert.NoError(t, err)
+	assert.Contains(t, result, "$env:SEMVER_MAJOR = \"2\"\n")
+}
+
+func TestFormatEnvDotenv(t *testing.T) {
+	v := semver.MustParse("2.0.0")
+	result, err := formatEnv(v, "", "dotenv")
+	assert.NoError(t, err)
+	assert.Contains(t, result, "MINOR=0\n")
+}
+
+func TestFormatEnvUnknownShell(t *testing.T) {
+	v := semver.MustParse("2.0.0")
+	_, err := formatEnv(v, "SEMVER_", "tcsh")
+	assert.Error(t, err)
+}
+
+func TestLatestTag(t *testing.T) {
+	repo := setupRepoWithTags(t)
+
+	result, err := latestTag(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.0", result.Original())
+}
+
+func TestLatestTagNoTags(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+
+	_, err = latestTag(repo)
+	assert.ErrorIs(t, err, ErrNoSemverTags)
+}
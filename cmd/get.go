@@ -0,0 +1,144 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/cobra"
+)
+
+// versionParts are the values available to format templates.
+type versionParts struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Metadata   string
+	// Core is MAJOR.MINOR.PATCH without prerelease or metadata
+	Core string
+	// Original is the version exactly as it was given, including any "v" prefix
+	Original string
+}
+
+func newVersionParts(v *semver.Version) versionParts {
+	return versionParts{
+		Major:      v.Major(),
+		Minor:      v.Minor(),
+		Patch:      v.Patch(),
+		Prerelease: v.Prerelease(),
+		Metadata:   v.Metadata(),
+		Core:       fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch()),
+		Original:   v.Original(),
+	}
+}
+
+// versionPartNames are the parts that can be extracted with "get <part>"
+var versionPartNames = []string{"major", "minor", "patch", "prerelease", "metadata", "core"}
+
+// GetVersionPart returns a single named part of a version
+func GetVersionPart(versionString, part string) (string, error) {
+	v, err := semver.NewVersion(versionString)
+	if err != nil {
+		return "", fmt.Errorf("invalid version: %s", versionString)
+	}
+	p := newVersionParts(v)
+
+	switch strings.ToLower(part) {
+	case "major":
+		return fmt.Sprint(p.Major), nil
+	case "minor":
+		return fmt.Sprint(p.Minor), nil
+	case "patch":
+		return fmt.Sprint(p.Patch), nil
+	case "prerelease":
+		return p.Prerelease, nil
+	case "metadata":
+		return p.Metadata, nil
+	case "core":
+		return p.Core, nil
+	}
+	return "", fmt.Errorf("unknown version part: %s", part)
+}
+
+// FormatVersion renders a version through a Go template, e.g. "{{.Major}}.{{.Minor}}"
+func FormatVersion(versionString, format string) (string, error) {
+	v, err := semver.NewVersion(versionString)
+	if err != nil {
+		return "", fmt.Errorf("invalid version: %s", versionString)
+	}
+
+	tmpl, err := template.New("format").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, newVersionParts(v)); err != nil {
+		return "", fmt.Errorf("could not render template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// getCmd represents the get command
+var getCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Extract or reformat parts of a version",
+	Long: `Extract a single part of a semver version, or reformat it with a template.
+
+Examples:
+	semvertool get major 1.2.3-rc.1
+	1
+
+	semvertool get core v1.2.3-rc.1+build.5
+	1.2.3
+
+	semvertool get format '{{.Major}}.{{.Minor}}' 1.2.3
+	1.2`,
+}
+
+// formatCmd represents the format subcommand
+var formatCmd = &cobra.Command{
+	Use:   "format <template> <version>",
+	Short: "Render a version through a Go template",
+	Long: `Render a version through a Go template.
+
+The template can use .Major, .Minor, .Patch, .Prerelease, .Metadata,
+.Core (MAJOR.MINOR.PATCH) and .Original (the version as given).`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := FormatVersion(args[1], args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(result)
+		return nil
+	},
+}
+
+func newGetPartCmd(part string) *cobra.Command {
+	return &cobra.Command{
+		Use:   part + " <version>",
+		Short: fmt.Sprintf("Print the %s part of a version", part),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := GetVersionPart(args[0], part)
+			if err != nil {
+				return err
+			}
+			fmt.Println(result)
+			return nil
+		},
+	}
+}
+
+func init() {
+	for _, part := range versionPartNames {
+		getCmd.AddCommand(newGetPartCmd(part))
+	}
+	getCmd.AddCommand(formatCmd)
+}
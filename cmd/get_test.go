@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVersionPartMajor(t *testing.T) {
+	result, err := GetVersionPart("1.2.3-rc.1", "major")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", result)
+}
+
+func TestGetVersionPartPrerelease(t *testing.T) {
+	result, err := GetVersionPart("1.2.3-rc.1", "prerelease")
+	assert.NoError(t, err)
+	assert.Equal(t, "rc.1", result)
+}
+
+func TestGetVersionPartMetadata(t *testing.T) {
+	result, err := GetVersionPart("1.2.3+build.5", "metadata")
+	assert.NoError(t, err)
+	assert.Equal(t, "build.5", result)
+}
+
+func TestGetVersionPartCore(t *testing.T) {
+	result, err := GetVersionPart("v1.2.3-rc.1+build.5", "core")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", result)
+}
+
+func TestGetVersionPartUnknownPart(t *testing.T) {
+	_, err := GetVersionPart("1.2.3", "micro")
+	assert.Error(t, err)
+}
+
+func TestGetVersionPartInvalidVersion(t *testing.T) {
+	_, err := GetVersionPart("invalid", "major")
+	assert.Error(t, err)
+}
+
+func TestFormatVersionMajorMinor(t *testing.T) {
+	result, err := FormatVersion("1.2.3", "{{.Major}}.{{.Minor}}")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2", result)
+}
+
+func TestFormatVersionConditionalPrerelease(t *testing.T) {
+	format := "{{.Core}}{{if .Prerelease}}-{{.Prerelease}}{{end}}"
+
+	result, err := FormatVersion("v1.2.3-rc.1+build.5", format)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3-rc.1", result)
+
+	result, err = FormatVersion("v1.2.3+build.5", format)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", result)
+}
+
+func TestFormatVersionInvalidTemplate(t *testing.T) {
+	_, err := FormatVersion("1.2.3", "{{.Major")
+	assert.Error(t, err)
+}
+
+func TestFormatVersionUnknownField(t *testing.T) {
+	_, err := FormatVersion("1.2.3", "{{.Micro}}")
+	assert.Error(t, err)
+}
@@ -5,6 +5,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/spf13/cobra"
@@ -24,6 +26,9 @@ var gitCmd = &cobra.Command{
 	Long: `
 	Bump a version based on the latest semver tag in the git repository.
 
+	If HEAD is already tagged with a semver tag, that version is returned as it is,
+	so re-running a release job doesn't bump twice. Use --force to bump anyway.
+
 	Examples:
 	git tag v0.1.0
 	semvertool git 
@@ -52,6 +57,18 @@ var gitCmd = &cobra.Command{
 	git tag v1.1.0
 	semvertool git --prerelease
 	v1.1.1-alpha.1
+
+	git tag v1.2.0
+	git commit -m "..." && git commit -m "..."
+	semvertool git --prerelease --prerelease-commits
+	v1.2.1-prerelease.2
+
+	semvertool git --minor --verify --dry-run
+	Version:  1.3.0
+	Tag:      v1.3.0
+	Commit:   3f6d1270a4c8e0b5d9f2e7c6b1a3d5f7e9c0b2a4
+	Files:    none
+	Pushes:   none
 	`,
 	Run: runGit,
 }
@@ -68,51 +85,289 @@ func init() {
 	cf := getCommonBumpFlags()
 	gitCmd.Flags().AddFlagSet(cf)
 	gitCmd.Flags().BoolP("hash", "s", false, "Append the short hash (sha) to the version as metadata information.")
-	gitCmd.Flags().BoolP("from-commit", "c", false, "Extract the bump type from a commit message")
-	gitCmd.MarkFlagsMutuallyExclusive("major", "minor", "patch", "prerelease", "from-message", "from-commit")
+	gitCmd.Flags().BoolP("from-commit", "c", false, "Choose the bump type from the commits since the latest tag, using the markers and rules in the config file")
+	gitCmd.Flags().String("metadata-template", "", "Go template for the metadata, using {{.ShortSHA}}, {{.SHA}}, {{.Branch}}, {{.CommitDate}}, {{.BuildNumber}} and {{.Dirty}}")
+	gitCmd.Flags().Int("sha-length", defaultSHALength, "Number of characters of the commit hash used for --hash and {{.ShortSHA}}")
+	gitCmd.Flags().Bool("prerelease-commits", false, "Number prerelease versions by the commits since the base tag instead of incrementing the last prerelease")
+	gitCmd.Flags().String("prerelease-since", "", "Count commits since this ref instead of the base tag for --prerelease-commits")
+	gitCmd.MarkFlagsMutuallyExclusive("major", "minor", "patch", "prerelease", "from-message", "from-commit", "release-1.0")
+	gitCmd.Flags().Bool("force", false, "Bump even if HEAD is already tagged, instead of returning the existing version")
+	gitCmd.Flags().String("line", "", "Bump within a version line such as 2.x or 2.3.x (defaults to the line in a release-2.x branch name)")
+	gitCmd.Flags().Bool("verify", false, "Fail if HEAD is already tagged, the new version already exists as a tag, or it isn't greater than the latest reachable tag")
+	gitCmd.MarkFlagsMutuallyExclusive("hash", "metadata-template")
 
 	deprecatedGitCmd.Flags().AddFlagSet(cf)
 	deprecatedGitCmd.Flags().BoolP("hash", "s", false, "Append the short hash (sha) to the version as metadata information.")
-	deprecatedGitCmd.Flags().BoolP("from-commit", "c", false, "Extract the bump type from a commit message")
+	deprecatedGitCmd.Flags().BoolP("from-commit", "c", false, "Choose the bump type from the commits since the latest tag, using the markers and rules in the config file")
+	deprecatedGitCmd.Flags().Bool("force", false, "Bump even if HEAD is already tagged, instead of returning the existing version")
 	deprecatedGitCmd.MarkFlagsMutuallyExclusive("major", "minor", "patch", "prerelease", "from-message", "from-commit")
 
 }
 
 func gitBump(repo *goget.Repository) (*semver.Version, error) {
-	bumpType := getBumpType()
+	result, err := gitBumpWithResult(repo)
+	if err != nil {
+		return nil, err
+	}
+	return result.Current, nil
+}
 
+// gitBumpWithResult bumps the latest semver tag and also reports the tag it started
+// from and the kind of bump applied.
+func gitBumpWithResult(repo *goget.Repository) (*bumpResult, error) {
 	semverTags, err := getTags(repo)
 	if err != nil {
-		fmt.Println("Could not get tags", err)
+		fmt.Fprintln(os.Stderr, "Could not get tags", err)
 		return nil, err
 	}
 
 	if len(semverTags) == 0 {
-		fmt.Println("No semver tags found")
+		fmt.Fprintln(os.Stderr, "No semver tags found")
 		return nil, ErrNoSemverTags
 	}
+	line, err := getVersionLine(repo)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Could not get the version line", err)
+		return nil, err
+	}
+	if line != nil {
+		semverTags = FilterLine(semverTags, *line)
+		explain("Restricting the base to the %d semver tags in line %s", len(semverTags), line)
+		if len(semverTags) == 0 {
+			fmt.Fprintf(os.Stderr, "No semver tags found in line %s\n", line)
+			return nil, fmt.Errorf("%w in line %s", ErrNoSemverTags, line)
+		}
+	}
 	latestSemverTag := semverTags[len(semverTags)-1]
+	explain("Using %s as the base, the highest of %d semver tags", latestSemverTag.Original(), len(semverTags))
+
+	if !viper.GetBool("force") {
+		tagged, err := headTag(repo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Could not get the tags on HEAD", err)
+			return nil, err
+		}
+		if tagged != nil {
+			fmt.Fprintf(os.Stderr, "HEAD is already tagged as %s, not bumping (use --force to bump anyway)\n", tagged.Original())
+			return &bumpResult{Previous: tagged, Current: tagged, BumpType: NoBump}, nil
+		}
+	}
 
-	newVersion, err := doBump(latestSemverTag.Original(), bumpType)
+	var bumpType BumpType
+	// target is the version set by an explicit bump
+	var target *semver.Version
+	if viper.GetBool("from-commit") {
+		bumpType, target, err = getBumpTypeFromCommits(repo, latestSemverTag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Could not get the bump type from the commits", err)
+			return nil, err
+		}
+	} else {
+		bumpType, target, err = getBumpTarget()
+		if err != nil {
+			return nil, err
+		}
+	}
+	bumpType, err = initialDevelopmentBump(latestSemverTag.Original(), bumpType)
 	if err != nil {
-		fmt.Println("Could not bump version", err)
+		fmt.Fprintln(os.Stderr, "Could not bump version", err)
 		return nil, err
 	}
-	if viper.GetBool("hash") {
+	if bumpType == NoBump {
+		// A skip marker keeps the current version
+		explain("Keeping %s", latestSemverTag.Original())
+		return &bumpResult{Previous: latestSemverTag, Current: latestSemverTag, BumpType: NoBump}, nil
+	}
 
-		hash, err := repo.ResolveRevision(plumbing.Revision("HEAD"))
+	var newVersion *semver.Version
+	switch {
+	case bumpType == ExplicitBump:
+		newVersion, err = explicitBump(latestSemverTag.Original(), target)
+	case bumpType == PrereleaseBump && viper.GetBool("prerelease-commits"):
+		newVersion, err = commitCountPrerelease(repo, latestSemverTag, viper.GetString("prerelease-since"))
+	default:
+		newVersion, err = doBump(latestSemverTag.Original(), bumpType)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Could not bump version", err)
+		return nil, err
+	}
+	if bumpType == ExplicitBump {
+		bumpType = bumpTypeBetween(latestSemverTag, newVersion)
+	}
+	explain("Bumped %s to %s", latestSemverTag.Original(), newVersion.String())
+	if line != nil && !line.contains(newVersion) {
+		return nil, fmt.Errorf("%w: %s bump to %s leaves line %s", ErrOutsideLine, bumpType, newVersion, line)
+	}
+	metadataTemplate := viper.GetString("metadata-template")
+	if viper.GetBool("hash") {
+		metadataTemplate = "{{.ShortSHA}}"
+	}
+	if metadataTemplate != "" {
+		values, err := getMetadataValues(repo, viper.GetInt("sha-length"))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Could not get metadata for HEAD", err)
+			return nil, err
+		}
+		metadata, err := renderMetadata(values, metadataTemplate)
 		if err != nil {
-			fmt.Println("Could not get revision hash of HEAD", err)
+			fmt.Fprintln(os.Stderr, "Could not render metadata", err)
 			return nil, err
 		}
-		newV, err := newVersion.SetMetadata(hash.String()[:7])
+		newV, err := newVersion.SetMetadata(metadata)
 		if err != nil {
-			fmt.Println("Could not add hash metadata", err)
+			fmt.Fprintln(os.Stderr, "Could not add metadata", err)
 			return nil, err
 		}
+		explain("Added metadata %s from template %q", metadata, metadataTemplate)
 		newVersion = &newV
 	}
-	return newVersion, nil
+	result := &bumpResult{Previous: latestSemverTag, Current: newVersion, BumpType: bumpType}
+	if err := enforcePolicy(repo, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// headTag returns the highest semver tag on HEAD, or nil if HEAD isn't tagged
+func headTag(repo *goget.Repository) (*semver.Version, error) {
+	headRef, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HEAD: %w", err)
+	}
+	tagCommits, err := getTagCommits(repo)
+	if err != nil {
+		return nil, err
+	}
+	var highest *semver.Version
+	for _, v := range tagCommits[headRef.Hash()] {
+		if highest == nil || v.GreaterThan(highest) {
+			highest = v
+		}
+	}
+	return highest, nil
+}
+
+// getBumpTypeFromCommits chooses the bump type from the commits since the latest tag,
+// using the rules and markers from the config file. An explicit version from a marker
+// wins and is returned with an ExplicitBump, then the highest bump found, then a skip
+// marker as NoBump. Without any, it's a patch bump.
+func getBumpTypeFromCommits(repo *goget.Repository, latest *semver.Version) (BumpType, *semver.Version, error) {
+	c, err := loadConfig(cfgFile)
+	if err != nil {
+		return UnknownBump, nil, err
+	}
+	base, err := getTagCommit(repo, latest.Original())
+	if err != nil {
+		return UnknownBump, nil, fmt.Errorf("could not resolve tag %s: %w", latest.Original(), err)
+	}
+	explain("Scanning the commits since %s (%s) with %d rules", latest.Original(), base.String()[:defaultSHALength], len(c.Rules))
+	decision, err := bumpFromCommits(repo, base, c.Rules, append(c.Markers, defaultMarkerPatterns...))
+	if err != nil {
+		return UnknownBump, nil, err
+	}
+	if decision == nil {
+		fmt.Fprintf(os.Stderr, "No rules or markers matched the commits since %s, using a patch bump\n", latest.Original())
+		return PatchBump, nil, nil
+	}
+	short := decision.Commit.String()[:defaultSHALength]
+	switch {
+	case decision.Version != nil:
+		fmt.Fprintf(os.Stderr, "Using version %s from %s on commit %s\n", decision.Version, decision.Source, short)
+	case decision.BumpType == NoBump:
+		fmt.Fprintf(os.Stderr, "Skipping the release for %s on commit %s\n", decision.Source, short)
+	default:
+		fmt.Fprintf(os.Stderr, "Using %s bump from %s on commit %s\n", decision.BumpType, decision.Source, short)
+	}
+	return decision.BumpType, decision.Version, nil
+}
+
+// getTagCommit returns the commit a tag points at, peeling annotated tags
+func getTagCommit(repo *goget.Repository, tag string) (plumbing.Hash, error) {
+	ref, err := repo.Tag(tag)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	tagObject, err := repo.TagObject(ref.Hash())
+	if err == plumbing.ErrObjectNotFound {
+		return ref.Hash(), nil
+	} else if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	commit, err := tagObject.Commit()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return commit.Hash, nil
+}
+
+// commitCountPrerelease makes a prerelease of base numbered by the commits since the
+// base tag (or since the given ref), so that builds of different commits on a branch
+// get unique, increasing versions without tagging every build.
+// A release base gets the next patch version with the prerelease prefix and count,
+// e.g. 1.2.4-prerelease.7, while a prerelease base has the count added to its own
+// prerelease, e.g. 1.3.0-rc.1.7, so it still sorts after the base.
+func commitCountPrerelease(repo *goget.Repository, base *semver.Version, since string) (*semver.Version, error) {
+	if scheme := viper.GetString("scheme"); scheme != "" && scheme != "semver" {
+		return nil, fmt.Errorf("--prerelease-commits can't be used with --scheme %s", scheme)
+	}
+	goVersion := viper.GetBool("go")
+	if goVersion {
+		if err := ValidateGoVersion(base.Original()); err != nil {
+			return nil, err
+		}
+	}
+
+	var sinceCommit plumbing.Hash
+	if since == "" {
+		hash, err := getTagCommit(repo, base.Original())
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve tag %s: %w", base.Original(), err)
+		}
+		sinceCommit = hash
+	} else {
+		hash, err := repo.ResolveRevision(plumbing.Revision(since))
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve %s: %w", since, err)
+		}
+		sinceCommit = *hash
+	}
+
+	head, err := repo.ResolveRevision(plumbing.Revision("HEAD"))
+	if err != nil {
+		return nil, fmt.Errorf("could not get revision hash of HEAD: %w", err)
+	}
+	count, err := commitsSince(repo, *head, sinceCommit)
+	if err != nil {
+		return nil, err
+	}
+	explain("Numbering the prerelease with the %d commits since %s", count, sinceCommit.String()[:defaultSHALength])
+
+	var newV semver.Version
+	if base.Prerelease() == "" {
+		newV, err = base.IncPatch().SetPrerelease(fmt.Sprintf("%s.%d", viper.GetString("prerelease-prefix"), count))
+	} else {
+		newV, err = base.SetPrerelease(fmt.Sprintf("%s.%d", base.Prerelease(), count))
+		if err == nil {
+			newV, err = newV.SetMetadata("")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if goVersion {
+		// Keep +incompatible, as goBump does
+		if base.Metadata() == strings.TrimPrefix(goIncompatible, "+") {
+			newV, err = newV.SetMetadata(base.Metadata())
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := ValidateGoVersion(newV.Original()); err != nil {
+			return nil, err
+		}
+	}
+	return &newV, nil
 }
 
 func runGit(cmd *cobra.Command, args []string) {
@@ -129,16 +384,41 @@ func runGit(cmd *cobra.Command, args []string) {
 
 	repo, err := goget.PlainOpenWithOptions(".", &goget.PlainOpenOptions{DetectDotGit: true})
 	if err != nil {
-		fmt.Println("Could not open git repository in current directory", err)
-		return
+		fmt.Fprintln(os.Stderr, "Could not open git repository in current directory:", err)
+		os.Exit(1)
 	}
 
-	newVersion, err := gitBump(repo)
+	// Errors go to stderr with a non-zero exit, so $(semvertool bump git) never
+	// captures them as the version
+	result, err := gitBumpWithResult(repo)
 	if err != nil {
-		fmt.Println("Could not bump version", err)
+		fmt.Fprintln(os.Stderr, "Could not bump version:", err)
+		os.Exit(1)
+	}
+
+	if viper.GetBool("verify") {
+		if err := verifyBump(repo, result.Current); err != nil {
+			fmt.Fprintln(os.Stderr, "Verification failed:", err)
+			os.Exit(1)
+		}
+	}
+
+	if viper.GetBool("dry-run") {
+		plan, err := planRelease(repo, result)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Could not plan the release:", err)
+			os.Exit(1)
+		}
+		_ = writeReleasePlan(os.Stdout, plan)
 		return
 	}
 
-	fmt.Println(newVersion.String())
+	fmt.Println(printedVersion(result.Current))
 
+	if viper.GetBool("ci-output") {
+		if err := writeCIOutput(os.Stdout, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not write CI output: %s\n", err)
+			os.Exit(1)
+		}
+	}
 }
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -183,6 +188,9 @@ func TestGitBumpOneTag(t *testing.T) {
 	_, err = repo.CreateTag("v1.0.0", commit, nil)
 	assert.NoError(t, err)
 
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
 	result, err := gitBump(repo)
 	expected := "v1.0.1"
 	assert.NoError(t, err)
@@ -205,6 +213,9 @@ func TestGitBumpMultipleTags(t *testing.T) {
 	_, err = repo.CreateTag("v1.1.0", commit, nil)
 	assert.NoError(t, err)
 
+	_, err = commitFile("file3.txt", repo)
+	assert.NoError(t, err)
+
 	result, err := gitBump(repo)
 	expected := "v1.1.1"
 	assert.NoError(t, err)
@@ -221,6 +232,9 @@ func TestGitBumpWithHash(t *testing.T) {
 	_, err = repo.CreateTag("v1.0.0", commitHash, nil)
 	assert.NoError(t, err)
 
+	commitHash, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
 	shortHash := commitHash.String()[:7]
 
 	viper.Set("hash", true)
@@ -241,6 +255,9 @@ func TestGitBumpWithPrereleasePrefix(t *testing.T) {
 	_, err = repo.CreateTag("v1.0.0", commit, nil)
 	assert.NoError(t, err)
 
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
 	viper.Set("prerelease", true)
 	viper.Set("prerelease-prefix", "alpha")
 	result, err := gitBump(repo)
@@ -248,3 +265,310 @@ func TestGitBumpWithPrereleasePrefix(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, result.Original())
 }
+
+func TestGitBumpPrereleaseCommitCount(t *testing.T) {
+	viper.Reset()
+	repo := setupRepoWithTags(t)
+	_, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+	_, err = commitFile("file6.txt", repo)
+	assert.NoError(t, err)
+
+	viper.Set("prerelease", true)
+	viper.Set("prerelease-prefix", "alpha")
+	viper.Set("prerelease-commits", true)
+	result, err := gitBump(repo)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.1-alpha.2", result.Original())
+}
+
+func TestGitBumpPrereleaseCommitCountFromPrerelease(t *testing.T) {
+	viper.Reset()
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.3.0-rc.1", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
+	viper.Set("prerelease", true)
+	viper.Set("prerelease-commits", true)
+	result, err := gitBump(repo)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.3.0-rc.1.1", result.Original())
+}
+
+func TestGitBumpPrereleaseCommitCountSinceRef(t *testing.T) {
+	viper.Reset()
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	assert.NoError(t, err)
+	for _, f := range []string{"file2.txt", "file3.txt", "file4.txt"} {
+		_, err = commitFile(f, repo)
+		assert.NoError(t, err)
+	}
+
+	viper.Set("prerelease", true)
+	viper.Set("prerelease-prefix", "build")
+	viper.Set("prerelease-commits", true)
+	viper.Set("prerelease-since", "HEAD~1")
+	result, err := gitBump(repo)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.1-build.1", result.Original())
+}
+
+func TestGitBumpPrereleaseCommitCountGo(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v2.1.0+incompatible", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
+	viper.Set("prerelease", true)
+	viper.Set("prerelease-prefix", "build")
+	viper.Set("prerelease-commits", true)
+	viper.Set("go", true)
+	result, err := gitBump(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.1.1-build.1+incompatible", result.Original())
+}
+
+func TestGitBumpPrereleaseCommitCountCalver(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	repo := setupRepoWithTags(t)
+	_, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+
+	viper.Set("prerelease", true)
+	viper.Set("prerelease-commits", true)
+	viper.Set("scheme", "calver:YYYY.MM.MICRO")
+	_, err = gitBump(repo)
+	assert.ErrorContains(t, err, "--prerelease-commits can't be used with --scheme")
+}
+
+func TestGetTagCommitAnnotated(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, &git.CreateTagOptions{
+		Message: "Release v1.0.0",
+		Tagger:  &object.Signature{Name: "Test Author", Email: "test_email@example.com"},
+	})
+	assert.NoError(t, err)
+
+	result, err := getTagCommit(repo, "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, commit, result)
+}
+
+func TestGetTagInfos(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+
+	tagDate := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	commit1, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit1, &git.CreateTagOptions{
+		Message: "Release v1.0.0",
+		Tagger:  &object.Signature{Name: "Test Author", Email: "test_email@example.com", When: tagDate},
+	})
+	assert.NoError(t, err)
+
+	commit2, err := commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.1.0", commit2, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("not-semver", commit2, nil)
+	assert.NoError(t, err)
+
+	tags, err := getTagInfos(repo)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+
+	byName := map[string]*tagInfo{}
+	for _, tag := range tags {
+		byName[tag.Version.Original()] = tag
+	}
+
+	annotated := byName["v1.0.0"]
+	assert.True(t, annotated.Annotated)
+	assert.Equal(t, commit1, annotated.Commit)
+	assert.True(t, tagDate.Equal(annotated.Date))
+	assert.Equal(t, "Release v1.0.0\n", annotated.Message)
+
+	lightweight := byName["v1.1.0"]
+	assert.False(t, lightweight.Annotated)
+	assert.Equal(t, commit2, lightweight.Commit)
+	commit, err := repo.CommitObject(commit2)
+	assert.NoError(t, err)
+	assert.True(t, commit.Committer.When.Equal(lightweight.Date))
+}
+
+func TestGitBumpInitialDevelopment(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v0.4.2", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("initial-development", true)
+	viper.Set("from-message", "Remove the old API [bump major]")
+	result, err := gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.5.0", result.Current.String())
+	assert.Equal(t, MinorBump, result.BumpType)
+}
+
+func TestGitBumpExplain(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	_, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("explain", true)
+	viper.Set("minor", true)
+
+	r, w, _ := os.Pipe()
+	originalStderr := os.Stderr
+	os.Stderr = w
+	_, err = gitBumpWithResult(repo)
+	w.Close()
+	os.Stderr = originalStderr
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(r)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "explain: Found tag v1.2.0-alpha.1\n")
+	assert.Contains(t, buf.String(), "explain: Using v1.2.0 as the base, the highest of 4 semver tags\n")
+	assert.Contains(t, buf.String(), "explain: Using minor bump from --minor\n")
+	assert.Contains(t, buf.String(), "explain: Bumped v1.2.0 to 1.3.0\n")
+}
+
+func TestGitBumpWithoutExplain(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	_, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+	viper.Reset()
+
+	r, w, _ := os.Pipe()
+	originalStderr := os.Stderr
+	os.Stderr = w
+	_, err = gitBumpWithResult(repo)
+	w.Close()
+	os.Stderr = originalStderr
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(r)
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestGitBumpTaggedHead(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("minor", true)
+
+	result, err := gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.0", result.Current.Original())
+	assert.Equal(t, NoBump, result.BumpType)
+
+	viper.Set("force", true)
+	result, err = gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.0", result.Current.String())
+	assert.Equal(t, MinorBump, result.BumpType)
+}
+
+func TestHeadTag(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+
+	tagged, err := headTag(repo)
+	assert.NoError(t, err)
+	assert.Nil(t, tagged)
+
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0-rc.1", commit, nil)
+	assert.NoError(t, err)
+	tagged, err = headTag(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", tagged.Original())
+}
+
+func TestGitBumpGoKeepsPrefix(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
+	result, err := gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", printedVersion(result.Current))
+
+	viper.Set("go", true)
+	result, err = gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.1", printedVersion(result.Current))
+}
+
+func TestGitBumpWarnsOncePerTag(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	head, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.2.3.4", head, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file6.txt", repo)
+	assert.NoError(t, err)
+	viper.Reset()
+	defer viper.Reset()
+	delete(warnedTags, "v1.2.3.4")
+
+	r, w, _ := os.Pipe()
+	originalStderr := os.Stderr
+	os.Stderr = w
+	result, err := gitBumpWithResult(repo)
+	if err == nil {
+		err = verifyBump(repo, result.Current)
+	}
+	w.Close()
+	os.Stderr = originalStderr
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(r)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(buf.String(), "Warning: could not parse tag v1.2.3.4"))
+}
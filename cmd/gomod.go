@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	gosemver "golang.org/x/mod/semver"
+)
+
+const goIncompatible = "+incompatible"
+
+var ErrGoModulePath = errors.New("module path does not match major version")
+
+// ValidateGoVersion checks a version against the rules the go command applies to
+// module versions: a mandatory "v" prefix, all three components, and no build
+// metadata other than +incompatible (which is only allowed from v2 onwards).
+// Pseudo-versions are valid prereleases, so need no special handling here.
+func ValidateGoVersion(v string) error {
+	if !strings.HasPrefix(v, "v") {
+		return fmt.Errorf("go module version %s must start with \"v\"", v)
+	}
+	if !gosemver.IsValid(v) {
+		return fmt.Errorf("invalid go module version: %s", v)
+	}
+
+	build := gosemver.Build(v)
+	if build != "" && build != goIncompatible {
+		return fmt.Errorf("go module version %s can't have build metadata other than %s", v, goIncompatible)
+	}
+	if gosemver.Canonical(v)+build != v {
+		return fmt.Errorf("go module version %s must have major, minor and patch components", v)
+	}
+	if build == goIncompatible && (gosemver.Major(v) == "v0" || gosemver.Major(v) == "v1") {
+		return fmt.Errorf("go module version %s can't be %s below v2", v, goIncompatible)
+	}
+	return nil
+}
+
+// IsGoPseudoVersion reports whether v is a Go pseudo-version such as
+// v0.0.0-20230905200255-921286631fa9
+func IsGoPseudoVersion(v string) bool {
+	return module.IsPseudoVersion(v)
+}
+
+// goBump bumps a Go module version, keeping any +incompatible marker which semver
+// bumps would otherwise drop.
+func goBump(version string, bumpWhat BumpType) (*semver.Version, error) {
+	if err := ValidateGoVersion(version); err != nil {
+		return &semver.Version{}, err
+	}
+
+	newV, err := doSemverBump(version, bumpWhat)
+	if err != nil {
+		return &semver.Version{}, err
+	}
+	if gosemver.Build(version) == goIncompatible && newV.Metadata() == "" {
+		v, err := newV.SetMetadata(strings.TrimPrefix(goIncompatible, "+"))
+		if err != nil {
+			return &semver.Version{}, err
+		}
+		newV = &v
+	}
+	if err := ValidateGoVersion(newV.Original()); err != nil {
+		return &semver.Version{}, err
+	}
+
+	if bumpWhat == MajorBump {
+		if err := checkGoModulePath(viper.GetString("go-mod"), newV.Original()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+		}
+	}
+	return newV, nil
+}
+
+// checkGoModulePath returns an error if the module declared in the go.mod file at
+// path would need a /vN suffix (or a different one) to be published as version v.
+// A missing go.mod file is not an error, as the version may not be for this module.
+func checkGoModulePath(path string, v string) error {
+	modPath, err := readGoModulePath(path)
+	if err != nil || modPath == "" {
+		return err
+	}
+	prefix, pathMajor, ok := module.SplitPathVersion(modPath)
+	if !ok {
+		return fmt.Errorf("invalid module path %s in %s", modPath, path)
+	}
+	if err := module.CheckPathMajor(v, pathMajor); err != nil {
+		return fmt.Errorf("%w: %s declares module %s, publishing %s requires changing it to %s/%s",
+			ErrGoModulePath, path, modPath, v, prefix, gosemver.Major(v))
+	}
+	return nil
+}
+
+// readGoModulePath returns the module path declared in the go.mod file at path,
+// or an empty string if there is no such file.
+func readGoModulePath(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	modPath := modfile.ModulePath(data)
+	if modPath == "" {
+		return "", fmt.Errorf("no module path found in %s", path)
+	}
+	return modPath, nil
+}
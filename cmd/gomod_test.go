@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGoVersionValid(t *testing.T) {
+	for _, v := range []string{
+		"v1.2.3",
+		"v0.1.0-rc.1",
+		"v2.1.0+incompatible",
+		"v0.0.0-20230905200255-921286631fa9",
+		"v1.2.4-0.20230905200255-921286631fa9",
+	} {
+		assert.NoError(t, ValidateGoVersion(v), v)
+	}
+}
+
+func TestValidateGoVersionInvalid(t *testing.T) {
+	for _, v := range []string{
+		"1.2.3",
+		"v1.2",
+		"v1.2.3+build.5",
+		"v1.2.3+incompatible",
+		"v01.2.3",
+		"invalid",
+	} {
+		assert.Error(t, ValidateGoVersion(v), v)
+	}
+}
+
+func TestIsGoPseudoVersion(t *testing.T) {
+	assert.True(t, IsGoPseudoVersion("v0.0.0-20230905200255-921286631fa9"))
+	assert.True(t, IsGoPseudoVersion("v1.2.4-0.20230905200255-921286631fa9"))
+	assert.False(t, IsGoPseudoVersion("v1.2.4-rc.1"))
+}
+
+func TestGoBumpKeepsIncompatible(t *testing.T) {
+	viper.Reset()
+	result, err := goBump("v2.1.0+incompatible", PatchBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.1.1+incompatible", result.Original())
+}
+
+func TestGoBumpRequiresVPrefix(t *testing.T) {
+	viper.Reset()
+	_, err := goBump("1.2.3", PatchBump)
+	assert.Error(t, err)
+}
+
+func TestGoBumpPseudoVersion(t *testing.T) {
+	viper.Reset()
+	result, err := goBump("v1.2.4-0.20230905200255-921286631fa9", PatchBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.4", result.Original())
+}
+
+func TestDoBumpGoMode(t *testing.T) {
+	viper.Reset()
+	viper.Set("go", true)
+	_, err := doBump("1.2.3", PatchBump)
+	viper.Reset()
+	assert.Error(t, err)
+}
+
+func writeGoMod(t *testing.T, modulePath string) string {
+	path := filepath.Join(t.TempDir(), "go.mod")
+	assert.NoError(t, os.WriteFile(path, []byte("module "+modulePath+"\n\ngo 1.21\n"), 0644))
+	return path
+}
+
+func TestCheckGoModulePathV1(t *testing.T) {
+	path := writeGoMod(t, "github.com/example/lib")
+	assert.NoError(t, checkGoModulePath(path, "v1.0.0"))
+}
+
+func TestCheckGoModulePathNeedsSuffix(t *testing.T) {
+	path := writeGoMod(t, "github.com/example/lib")
+	err := checkGoModulePath(path, "v2.0.0")
+	assert.ErrorIs(t, err, ErrGoModulePath)
+	assert.Contains(t, err.Error(), "github.com/example/lib/v2")
+}
+
+func TestCheckGoModulePathWrongSuffix(t *testing.T) {
+	path := writeGoMod(t, "github.com/example/lib/v2")
+	err := checkGoModulePath(path, "v3.0.0")
+	assert.ErrorIs(t, err, ErrGoModulePath)
+	assert.Contains(t, err.Error(), "github.com/example/lib/v3")
+}
+
+func TestCheckGoModulePathMatchingSuffix(t *testing.T) {
+	path := writeGoMod(t, "github.com/example/lib/v3")
+	assert.NoError(t, checkGoModulePath(path, "v3.0.0"))
+}
+
+func TestCheckGoModulePathNoGoMod(t *testing.T) {
+	assert.NoError(t, checkGoModulePath(filepath.Join(t.TempDir(), "go.mod"), "v2.0.0"))
+}
@@ -0,0 +1,212 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	gosort "sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	linesRepoPath string
+	linesByMinor  bool
+)
+
+var ErrOutsideLine = errors.New("version is outside the version line")
+
+var (
+	versionLinePattern = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.x)?$`)
+	// releaseBranch matches maintenance branches such as release-2.x or release/2.3.x
+	releaseBranch = regexp.MustCompile(`(?:^|/)release[-/](v?\d+(?:\.\d+)?(?:\.x)?)$`)
+)
+
+// versionLine is a major line such as 2.x, or a minor line such as 2.3.x
+type versionLine struct {
+	Major    uint64
+	Minor    uint64
+	HasMinor bool
+}
+
+func (l versionLine) String() string {
+	if l.HasMinor {
+		return fmt.Sprintf("%d.%d.x", l.Major, l.Minor)
+	}
+	return fmt.Sprintf("%d.x", l.Major)
+}
+
+// contains reports whether v belongs to the line
+func (l versionLine) contains(v *semver.Version) bool {
+	return v.Major() == l.Major && (!l.HasMinor || v.Minor() == l.Minor)
+}
+
+// parseVersionLine parses a line such as 2.x, 2, 2.3.x or 2.3
+func parseVersionLine(s string) (versionLine, error) {
+	m := versionLinePattern.FindStringSubmatch(s)
+	if m == nil {
+		return versionLine{}, fmt.Errorf("invalid version line %q, expected MAJOR.x or MAJOR.MINOR.x", s)
+	}
+	major, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return versionLine{}, fmt.Errorf("invalid version line %q: %w", s, err)
+	}
+	line := versionLine{Major: major}
+	if m[2] != "" {
+		minor, err := strconv.ParseUint(m[2], 10, 64)
+		if err != nil {
+			return versionLine{}, fmt.Errorf("invalid version line %q: %w", s, err)
+		}
+		line.Minor = minor
+		line.HasMinor = true
+	}
+	return line, nil
+}
+
+// getVersionLine returns the line given with --line, or the one in the name of a
+// release branch such as release-2.x. It returns nil if there is neither.
+func getVersionLine(repo *goget.Repository) (*versionLine, error) {
+	if s := viper.GetString("line"); s != "" {
+		line, err := parseVersionLine(s)
+		if err != nil {
+			return nil, err
+		}
+		return &line, nil
+	}
+	branch := currentBranch(repo)
+	m := releaseBranch.FindStringSubmatch(branch)
+	if m == nil {
+		return nil, nil
+	}
+	line, err := parseVersionLine(m[1])
+	if err != nil {
+		return nil, err
+	}
+	explain("Using version line %s from branch %s", line, branch)
+	return &line, nil
+}
+
+// FilterLine returns the versions in a version line
+func FilterLine(versions []*semver.Version, line versionLine) []*semver.Version {
+	filtered := []*semver.Version{}
+	for _, v := range versions {
+		if line.contains(v) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// lineSummary is a row of the lines output
+type lineSummary struct {
+	Line versionLine
+	// Release is the latest release in the line, and Prerelease the latest prerelease
+	// if it's newer than that release
+	Release    *semver.Version
+	Prerelease *semver.Version
+}
+
+// summarizeLines groups versions into major lines, or minor lines with byMinor,
+// and finds the latest release and prerelease of each. The lines are in version order.
+func summarizeLines(versions []*semver.Version, byMinor bool) []lineSummary {
+	summaries := make(map[versionLine]*lineSummary)
+	for _, v := range versions {
+		line := versionLine{Major: v.Major()}
+		if byMinor {
+			line.Minor = v.Minor()
+			line.HasMinor = true
+		}
+		s, ok := summaries[line]
+		if !ok {
+			s = &lineSummary{Line: line}
+			summaries[line] = s
+		}
+		if v.Prerelease() == "" {
+			if s.Release == nil || v.GreaterThan(s.Release) {
+				s.Release = v
+			}
+		} else if s.Prerelease == nil || v.GreaterThan(s.Prerelease) {
+			s.Prerelease = v
+		}
+	}
+
+	result := make([]lineSummary, 0, len(summaries))
+	for _, s := range summaries {
+		if s.Prerelease != nil && s.Release != nil && !s.Prerelease.GreaterThan(s.Release) {
+			s.Prerelease = nil
+		}
+		result = append(result, *s)
+	}
+	gosort.Slice(result, func(i, j int) bool {
+		if result[i].Line.Major != result[j].Line.Major {
+			return result[i].Line.Major < result[j].Line.Major
+		}
+		return result[i].Line.Minor < result[j].Line.Minor
+	})
+	return result
+}
+
+func writeLines(out io.Writer, summaries []lineSummary) error {
+	orNone := func(v *semver.Version) string {
+		if v == nil {
+			return "-"
+		}
+		return v.Original()
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tRELEASE\tPRERELEASE")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.Line, orNone(s.Release), orNone(s.Prerelease))
+	}
+	return w.Flush()
+}
+
+// linesCmd represents the lines command
+var linesCmd = &cobra.Command{
+	Use:   "lines",
+	Short: "List the version lines with their latest release and prerelease",
+	Long: `List every version line that has semver tags, with its latest release and
+its latest prerelease, if that is newer than the release.
+
+Lines are major versions (1.x, 2.x), or major.minor versions (2.3.x) with
+--minor. Use bump git --line to bump within a line.`,
+	Run: runLines,
+}
+
+func init() {
+	linesCmd.Flags().StringVarP(&linesRepoPath, "repository", "r", ".", "Path to the git repository (defaults to current directory)")
+	linesCmd.Flags().BoolVar(&linesByMinor, "minor", false, "Group by major.minor instead of major version")
+}
+
+func runLines(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Unexpected arguments: %v\n", args)
+		_ = cmd.Help()
+		os.Exit(1)
+	}
+
+	repo, err := goget.PlainOpenWithOptions(linesRepoPath, &goget.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open git repository: %s\n", err)
+		os.Exit(1)
+	}
+	tags, err := getTags(repo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting tags: %s\n", err)
+		os.Exit(1)
+	}
+	if err := writeLines(os.Stdout, summarizeLines(tags, linesByMinor)); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+}
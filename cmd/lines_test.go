@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersionLine(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected versionLine
+	}{
+		{"2.x", versionLine{Major: 2}},
+		{"v2", versionLine{Major: 2}},
+		{"2.3.x", versionLine{Major: 2, Minor: 3, HasMinor: true}},
+		{"2.3", versionLine{Major: 2, Minor: 3, HasMinor: true}},
+	}
+	for _, tt := range tests {
+		line, err := parseVersionLine(tt.input)
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, tt.expected, line, tt.input)
+	}
+
+	for _, input := range []string{"", "x", "2.x.x", "2.3.4", "release-2.x"} {
+		_, err := parseVersionLine(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestFilterLine(t *testing.T) {
+	versions := []*semver.Version{
+		semver.MustParse("1.9.0"),
+		semver.MustParse("2.0.0"),
+		semver.MustParse("2.3.1"),
+		semver.MustParse("2.4.0-rc.1"),
+		semver.MustParse("3.0.0"),
+	}
+	assert.Equal(t, []string{"2.0.0", "2.3.1", "2.4.0-rc.1"}, VersionsToStrings(FilterLine(versions, versionLine{Major: 2})))
+	assert.Equal(t, []string{"2.3.1"}, VersionsToStrings(FilterLine(versions, versionLine{Major: 2, Minor: 3, HasMinor: true})))
+	assert.Empty(t, FilterLine(versions, versionLine{Major: 4}))
+}
+
+func TestSummarizeLines(t *testing.T) {
+	versions := []*semver.Version{
+		semver.MustParse("v1.4.2"),
+		semver.MustParse("v1.5.0-rc.1"),
+		semver.MustParse("v2.0.0"),
+		semver.MustParse("v2.1.0"),
+		semver.MustParse("v2.1.0-rc.1"),
+		semver.MustParse("v3.0.0-beta.1"),
+	}
+
+	var out bytes.Buffer
+	assert.NoError(t, writeLines(&out, summarizeLines(versions, false)))
+	expected := "LINE  RELEASE  PRERELEASE\n" +
+		"1.x   v1.4.2   v1.5.0-rc.1\n" +
+		"2.x   v2.1.0   -\n" +
+		"3.x   -        v3.0.0-beta.1\n"
+	assert.Equal(t, expected, out.String())
+
+	summaries := summarizeLines(versions, true)
+	lines := make([]string, len(summaries))
+	for i, s := range summaries {
+		lines[i] = s.Line.String()
+	}
+	assert.Equal(t, []string{"1.4.x", "1.5.x", "2.0.x", "2.1.x", "3.0.x"}, lines)
+}
+
+func setupRepoWithLines(t *testing.T) *git.Repository {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	for i, tag := range []string{"v1.0.0", "v1.1.0", "v2.0.0", "v2.1.0", "v3.0.0"} {
+		commit, err := commitFile(tag+".txt", repo)
+		assert.NoError(t, err, i)
+		_, err = repo.CreateTag(tag, commit, nil)
+		assert.NoError(t, err)
+	}
+	_, err = commitFile("file.txt", repo)
+	assert.NoError(t, err)
+	return repo
+}
+
+func TestGitBumpLine(t *testing.T) {
+	repo := setupRepoWithLines(t)
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("line", "2.x")
+	v, err := gitBump(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.1.1", v.Original())
+
+	viper.Set("minor", true)
+	v, err = gitBump(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.2.0", v.Original())
+
+	viper.Set("line", "1.0.x")
+	_, err = gitBump(repo)
+	assert.ErrorIs(t, err, ErrOutsideLine)
+
+	viper.Set("minor", false)
+	viper.Set("major", true)
+	viper.Set("line", "2.x")
+	_, err = gitBump(repo)
+	assert.ErrorIs(t, err, ErrOutsideLine)
+
+	viper.Set("major", false)
+	viper.Set("line", "4.x")
+	_, err = gitBump(repo)
+	assert.ErrorIs(t, err, ErrNoSemverTags)
+}
+
+func TestGitBumpLineFromBranch(t *testing.T) {
+	repo := setupRepoWithLines(t)
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("branch", "release-1.x")
+	v, err := gitBump(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.1", v.Original())
+
+	viper.Set("branch", "origin/release/2.0.x")
+	v, err = gitBump(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2.0.1", v.Original())
+
+	viper.Set("branch", "main")
+	v, err = gitBump(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v3.0.1", v.Original())
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
+)
+
+// markerPattern is a regular expression that selects the bump from a commit message.
+// The bump type is taken from the "bump" named group if there is one, otherwise from
+// Bump, which may also be "none" to skip the release. A "version" named group sets
+// the version explicitly.
+type markerPattern struct {
+	Pattern string `mapstructure:"pattern"`
+	Bump    string `mapstructure:"bump"`
+}
+
+// defaultMarkerPatterns are checked after any patterns from the config file
+var defaultMarkerPatterns = []markerPattern{
+	{Pattern: `(?i)\[\s*(skip release|no bump)\s*\]`, Bump: "none"},
+	{Pattern: `(?i)\[bump\s+to\s+(?P<version>[^\s\]]+)\s*\]`},
+	{Pattern: `(?i)\[bump\s*(?P<bump>major|minor|patch|prerelease)\s*\]`},
+	{Pattern: `(?im)^Release-As:\s*(?P<version>\S+)\s*$`},
+	{Pattern: `(?im)^Semver:\s*(?P<bump>major|minor|patch|prerelease)\s*$`},
+}
+
+// messageMarker is the bump selected by a commit message
+type messageMarker struct {
+	BumpType BumpType
+	// Version is set when the message gives the version explicitly
+	Version *semver.Version
+	// Source is the text in the message that selected the bump
+	Source string
+}
+
+func (m *messageMarker) String() string {
+	switch {
+	case m.Version != nil:
+		return "version " + m.Version.String()
+	case m.BumpType == NoBump:
+		return "skip release"
+	}
+	return string(m.BumpType) + " bump"
+}
+
+var ErrVersionNotGreater = errors.New("new version is not greater than the current version")
+
+func parseBumpName(name string) (BumpType, error) {
+	switch strings.ToLower(name) {
+	case "major":
+		return MajorBump, nil
+	case "minor":
+		return MinorBump, nil
+	case "patch":
+		return PatchBump, nil
+	case "prerelease":
+		return PrereleaseBump, nil
+	case "none":
+		return NoBump, nil
+	}
+	return UnknownBump, fmt.Errorf("unknown bump type %q", name)
+}
+
+func (p markerPattern) validate() error {
+	re, err := regexp.Compile(p.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid marker pattern %q: %w", p.Pattern, err)
+	}
+	if p.Bump == "" && re.SubexpIndex("bump") < 0 && re.SubexpIndex("version") < 0 {
+		return fmt.Errorf("marker pattern %q needs a bump type, or a bump or version group", p.Pattern)
+	}
+	if p.Bump != "" {
+		if _, err := parseBumpName(p.Bump); err != nil {
+			return fmt.Errorf("marker pattern %q: %w", p.Pattern, err)
+		}
+	}
+	return nil
+}
+
+// match returns the marker if the pattern matches message, or nil
+func (p markerPattern) match(message string) (*messageMarker, error) {
+	re, err := regexp.Compile(p.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid marker pattern %q: %w", p.Pattern, err)
+	}
+	matches := re.FindStringSubmatch(message)
+	if matches == nil {
+		return nil, nil
+	}
+
+	marker := &messageMarker{Source: strings.TrimSpace(matches[0])}
+	if i := re.SubexpIndex("version"); i >= 0 && matches[i] != "" {
+		v, err := semver.NewVersion(matches[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in %q: %w", marker.Source, err)
+		}
+		marker.Version = v
+		return marker, nil
+	}
+	bump := p.Bump
+	if i := re.SubexpIndex("bump"); i >= 0 && matches[i] != "" {
+		bump = matches[i]
+	}
+	marker.BumpType, err = parseBumpName(bump)
+	if err != nil {
+		return nil, err
+	}
+	return marker, nil
+}
+
+// findMessageMarker checks the patterns in order, and returns the first that matches
+// message, or nil if none do
+func findMessageMarker(message string, patterns []markerPattern) (*messageMarker, error) {
+	for _, p := range patterns {
+		marker, err := p.match(message)
+		if err != nil || marker != nil {
+			return marker, err
+		}
+	}
+	return nil, nil
+}
+
+// getMessageMarker finds the marker in the message given with --from-message, using the
+// patterns from the config file and then the default ones
+func getMessageMarker() (*messageMarker, error) {
+	message := viper.GetString("from-message")
+	if message == "" {
+		return nil, nil
+	}
+	c, err := loadConfig(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	return findMessageMarker(message, append(c.Markers, defaultMarkerPatterns...))
+}
+
+// explicitBump returns target, the version set by a marker, checking that it is
+// greater than version
+func explicitBump(version string, target *semver.Version) (*semver.Version, error) {
+	if target == nil {
+		return nil, fmt.Errorf("no version found in the commit message")
+	}
+	explain("Setting the version %s given in the commit message", target)
+	if version == "" {
+		return target, nil
+	}
+	current, err := semver.NewVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	if !target.GreaterThan(current) {
+		return nil, fmt.Errorf("%w: %s is not greater than %s", ErrVersionNotGreater, target, version)
+	}
+	return target, nil
+}
+
+// reportMessageMarker tells the user why a bump was chosen, on stderr so it doesn't
+// mix with the version
+func reportMessageMarker(marker *messageMarker) {
+	fmt.Fprintf(os.Stderr, "Using %s from %q in the commit message\n", marker, marker.Source)
+}
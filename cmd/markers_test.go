@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindMessageMarker(t *testing.T) {
+	tests := []struct {
+		message  string
+		bumpType BumpType
+		version  string
+		source   string
+	}{
+		{"Fix the parser [bump minor]", MinorBump, "", "[bump minor]"},
+		{"Update docs [skip release]", NoBump, "", "[skip release]"},
+		{"Update docs [No Bump]", NoBump, "", "[No Bump]"},
+		{"Release it [bump to 2.0.0]", "", "2.0.0", "[bump to 2.0.0]"},
+		{"Release it\n\nRelease-As: v1.2.0\n", "", "1.2.0", "Release-As: v1.2.0"},
+		{"Add a flag\n\nSemver: minor\nSigned-off-by: Jane Doe", MinorBump, "", "Semver: minor"},
+		// Skipping wins over bumping
+		{"Revert [bump major] [skip release]", NoBump, "", "[skip release]"},
+	}
+	for _, tt := range tests {
+		marker, err := findMessageMarker(tt.message, defaultMarkerPatterns)
+		assert.NoError(t, err)
+		if !assert.NotNil(t, marker, tt.message) {
+			continue
+		}
+		assert.Equal(t, tt.source, marker.Source)
+		if tt.version != "" {
+			assert.Equal(t, tt.version, marker.Version.String())
+		} else {
+			assert.Nil(t, marker.Version)
+			assert.Equal(t, tt.bumpType, marker.BumpType)
+		}
+	}
+}
+
+func TestFindMessageMarkerNoMatch(t *testing.T) {
+	marker, err := findMessageMarker("Just a commit", defaultMarkerPatterns)
+	assert.NoError(t, err)
+	assert.Nil(t, marker)
+
+	_, err = findMessageMarker("[bump to banana]", defaultMarkerPatterns)
+	assert.Error(t, err)
+}
+
+func TestFindMessageMarkerCustomPatterns(t *testing.T) {
+	patterns := append([]markerPattern{
+		{Pattern: `(?m)^feat(\(.*\))?!:`, Bump: "major"},
+		{Pattern: `(?m)^feat(\(.*\))?:`, Bump: "minor"},
+	}, defaultMarkerPatterns...)
+
+	marker, err := findMessageMarker("feat(cli)!: remove --build", patterns)
+	assert.NoError(t, err)
+	assert.Equal(t, MajorBump, marker.BumpType)
+	assert.Equal(t, "feat(cli)!:", marker.Source)
+
+	marker, err = findMessageMarker("feat: add --dry-run", patterns)
+	assert.NoError(t, err)
+	assert.Equal(t, MinorBump, marker.BumpType)
+}
+
+func TestMarkerPatternValidate(t *testing.T) {
+	assert.NoError(t, markerPattern{Pattern: `^fix:`, Bump: "patch"}.validate())
+	assert.NoError(t, markerPattern{Pattern: `Version: (?P<version>\S+)`}.validate())
+	assert.Error(t, markerPattern{Pattern: `^fix:`}.validate())
+	assert.Error(t, markerPattern{Pattern: `^fix:`, Bump: "huge"}.validate())
+	assert.Error(t, markerPattern{Pattern: `(`, Bump: "patch"}.validate())
+}
+
+func TestMarkersFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "semvertool.yaml")
+	err := os.WriteFile(path, []byte("markers:\n  - pattern: '^fix:'\n    bump: patch\n"), 0o644)
+	assert.NoError(t, err)
+	oldCfgFile := cfgFile
+	cfgFile = path
+	defer func() { cfgFile = oldCfgFile }()
+	defer viper.Reset()
+
+	viper.Set("from-message", "fix: handle empty tags")
+	assert.Equal(t, PatchBump, getBumpType())
+}
+
+func TestBumpToExplicitVersion(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("from-message", "Release [bump to 2.0.0]")
+
+	bumpType, target, err := getBumpTarget()
+	assert.NoError(t, err)
+	assert.Equal(t, ExplicitBump, bumpType)
+	v, err := explicitBump("1.4.2", target)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.0", v.String())
+
+	_, err = explicitBump("2.1.0", target)
+	assert.ErrorIs(t, err, ErrVersionNotGreater)
+
+	// The version comes from the marker, not from --from-message
+	viper.Set("from-message", "")
+	v, err = explicitBump("1.4.2", target)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.0", v.String())
+
+	_, err = doBump("1.4.2", ExplicitBump)
+	assert.Error(t, err)
+}
+
+func TestGitBumpExplicitVersion(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	_, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("from-message", "Release\n\nRelease-As: 1.3.0\n")
+
+	result, err := gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.0", result.Current.String())
+	assert.Equal(t, MinorBump, result.BumpType)
+}
+
+func TestGitBumpSkipRelease(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	_, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("from-message", "Fix a typo [skip release]")
+
+	result, err := gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.0", result.Current.String())
+	assert.Equal(t, NoBump, result.BumpType)
+}
+
+func TestBumpTargetInvalidVersion(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("from-message", "Release\n\nRelease-As: banana")
+
+	_, _, err := getBumpTarget()
+	assert.Error(t, err)
+
+	_, err = doBump("1.0.0", UnknownBump)
+	assert.Error(t, err)
+}
+
+func TestGitBumpInvalidExplicitVersion(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("force", true)
+	viper.Set("from-message", "[bump to banana]")
+
+	_, err := gitBumpWithResult(repo)
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+
+	goget "github.com/go-git/go-git/v5"
+)
+
+const defaultSHALength = 7
+
+// buildNumberEnvVars are checked in order for the {{.BuildNumber}} metadata placeholder
+var buildNumberEnvVars = []string{
+	"SEMVERTOOL_BUILD_NUMBER",
+	"BUILD_NUMBER",      // Jenkins, TeamCity
+	"GITHUB_RUN_NUMBER", // GitHub Actions
+	"CI_PIPELINE_IID",   // GitLab CI
+	"BUILD_BUILDID",     // Azure Pipelines
+}
+
+// metadataValues are the fields available to metadata templates
+type metadataValues struct {
+	SHA         string
+	ShortSHA    string
+	Branch      string
+	CommitDate  string
+	BuildNumber string
+	Dirty       bool
+}
+
+var (
+	invalidMetadataChars = regexp.MustCompile(`[^0-9A-Za-z.-]+`)
+	repeatedDots         = regexp.MustCompile(`\.{2,}`)
+)
+
+// sanitizeMetadata turns s into valid semver build metadata: characters other than
+// ASCII alphanumerics, hyphens and dots are replaced with hyphens, and empty
+// identifiers are removed.
+func sanitizeMetadata(s string) string {
+	s = invalidMetadataChars.ReplaceAllString(s, "-")
+	s = repeatedDots.ReplaceAllString(s, ".")
+	return strings.Trim(s, ".")
+}
+
+var ErrInvalidMetadata = errors.New("invalid metadata")
+
+var metadataIdentifier = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+
+// validateMetadata checks s against the semver build metadata grammar: dot separated,
+// non-empty identifiers of ASCII alphanumerics and hyphens.
+func validateMetadata(s string) error {
+	for _, identifier := range strings.Split(s, ".") {
+		if !metadataIdentifier.MatchString(identifier) {
+			return fmt.Errorf("%w %q: identifiers must be non-empty and only contain [0-9A-Za-z-]", ErrInvalidMetadata, s)
+		}
+	}
+	return nil
+}
+
+func buildNumber() string {
+	for _, name := range buildNumberEnvVars {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// getMetadataValues collects the placeholder values for HEAD. shaLength sets the
+// length of .ShortSHA, using the default if it isn't positive.
+func getMetadataValues(repo *goget.Repository, shaLength int) (*metadataValues, error) {
+	headRef, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HEAD: %w", err)
+	}
+	commit, err := repo.CommitObject(headRef.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("error getting HEAD commit: %w", err)
+	}
+	dirty, err := isDirty(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error getting worktree status: %w", err)
+	}
+
+	branch := ""
+	if headRef.Name().IsBranch() {
+		branch = headRef.Name().Short()
+	}
+
+	sha := commit.Hash.String()
+	if shaLength <= 0 {
+		shaLength = defaultSHALength
+	} else if shaLength > len(sha) {
+		shaLength = len(sha)
+	}
+	return &metadataValues{
+		SHA:         sha,
+		ShortSHA:    sha[:shaLength],
+		Branch:      branch,
+		CommitDate:  commit.Committer.When.UTC().Format("20060102150405"),
+		BuildNumber: buildNumber(),
+		Dirty:       dirty,
+	}, nil
+}
+
+// renderMetadata renders a metadata template and sanitizes the result
+func renderMetadata(values *metadataValues, format string) (string, error) {
+	tmpl, err := template.New("metadata").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("invalid metadata template: %w", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, values); err != nil {
+		return "", fmt.Errorf("could not render metadata template: %w", err)
+	}
+	return sanitizeMetadata(b.String()), nil
+}
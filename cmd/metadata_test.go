@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeMetadata(t *testing.T) {
+	assert.Equal(t, "feature-foo-bar", sanitizeMetadata("feature/foo_bar"))
+	assert.Equal(t, "build.5", sanitizeMetadata("build..5."))
+	assert.Equal(t, "abc.dirty", sanitizeMetadata(".abc.dirty"))
+	assert.Equal(t, "", sanitizeMetadata(""))
+}
+
+func TestRenderMetadata(t *testing.T) {
+	values := &metadataValues{
+		SHA:         "3f6d1270a1b2c3d4e5f60718293a4b5c6d7e8f90",
+		ShortSHA:    "3f6d127",
+		Branch:      "feature/login",
+		CommitDate:  "20250314093000",
+		BuildNumber: "42",
+		Dirty:       true,
+	}
+
+	result, err := renderMetadata(values, "{{.Branch}}.{{.BuildNumber}}.{{.ShortSHA}}{{if .Dirty}}.dirty{{end}}")
+	assert.NoError(t, err)
+	assert.Equal(t, "feature-login.42.3f6d127.dirty", result)
+
+	result, err = renderMetadata(values, "{{slice .SHA 0 12}}.{{.CommitDate}}")
+	assert.NoError(t, err)
+	assert.Equal(t, "3f6d1270a1b2.20250314093000", result)
+}
+
+func TestRenderMetadataEmptyPlaceholder(t *testing.T) {
+	values := &metadataValues{ShortSHA: "3f6d127"}
+
+	result, err := renderMetadata(values, "{{.BuildNumber}}.{{.ShortSHA}}")
+	assert.NoError(t, err)
+	assert.Equal(t, "3f6d127", result)
+}
+
+func TestRenderMetadataInvalidTemplate(t *testing.T) {
+	_, err := renderMetadata(&metadataValues{}, "{{.Unknown}}")
+	assert.Error(t, err)
+}
+
+func TestBuildNumberFromEnv(t *testing.T) {
+	t.Setenv("SEMVERTOOL_BUILD_NUMBER", "")
+	t.Setenv("BUILD_NUMBER", "")
+	t.Setenv("GITHUB_RUN_NUMBER", "17")
+	assert.Equal(t, "17", buildNumber())
+}
+
+func TestGetMetadataValues(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+
+	values, err := getMetadataValues(repo, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, commit.String(), values.SHA)
+	assert.Equal(t, commit.String()[:10], values.ShortSHA)
+	assert.Equal(t, "master", values.Branch)
+	assert.Len(t, values.CommitDate, 14)
+	assert.False(t, values.Dirty)
+}
+
+func TestGitBumpWithMetadataTemplate(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SEMVERTOOL_BUILD_NUMBER", "42")
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	assert.NoError(t, err)
+	commit, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
+	viper.Set("metadata-template", "b{{.BuildNumber}}.{{.Branch}}.{{.ShortSHA}}")
+	viper.Set("sha-length", 12)
+	result, err := gitBump(repo)
+	viper.Reset()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.1+b42.master."+commit.String()[:12], result.Original())
+}
+
+func TestValidateMetadata(t *testing.T) {
+	for _, m := range []string{"build.5", "3f6d127", "exp-sha.5114f85", "001"} {
+		assert.NoError(t, validateMetadata(m), m)
+	}
+	for _, m := range []string{"", "build..5", "build.", "feature/login", "build_5"} {
+		assert.ErrorIs(t, validateMetadata(m), ErrInvalidMetadata, m)
+	}
+}
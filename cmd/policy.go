@@ -0,0 +1,174 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/spf13/viper"
+)
+
+var ErrPolicyViolation = errors.New("policy violation")
+
+var (
+	ErrBumpNotAllowed      = fmt.Errorf("%w: bump type not allowed on this branch", ErrPolicyViolation)
+	ErrZeroMajorNotAllowed = fmt.Errorf("%w: major bumps of 0.x versions are not allowed", ErrPolicyViolation)
+	ErrPrereleaseOnMain    = fmt.Errorf("%w: prereleases are not allowed on the main branch", ErrPolicyViolation)
+	ErrMajorNotAllowed     = fmt.Errorf("%w: major bumps need --allow-major", ErrPolicyViolation)
+)
+
+// branchEnvVars are checked in order for the branch name when HEAD isn't on a branch,
+// as is usual in CI
+var branchEnvVars = []string{
+	"SEMVERTOOL_BRANCH",
+	"GITHUB_HEAD_REF",        // GitHub Actions pull requests
+	"GITHUB_REF_NAME",        // GitHub Actions
+	"CI_COMMIT_REF_NAME",     // GitLab CI
+	"BUILD_SOURCEBRANCHNAME", // Azure Pipelines
+}
+
+// branchRule limits the bump types allowed on branches matching Name, a glob
+// pattern such as release/*
+type branchRule struct {
+	Name  string     `mapstructure:"name"`
+	Allow []BumpType `mapstructure:"allow"`
+}
+
+// bumpPolicy is the policy section of the config file, checked by bump and bump git
+// before a new version is returned
+type bumpPolicy struct {
+	// Branches are checked in order, and the first matching rule applies. Branches
+	// without a matching rule allow any bump type.
+	Branches              []branchRule `mapstructure:"branches"`
+	AllowZeroMajor        bool         `mapstructure:"allow-zero-major"`
+	AllowPrereleaseOnMain bool         `mapstructure:"allow-prerelease-on-main"`
+	MainBranches          []string     `mapstructure:"main-branches"`
+	RequireAllowMajor     bool         `mapstructure:"require-allow-major"`
+}
+
+// bumpRequest describes a bump for the policy to check
+type bumpRequest struct {
+	Branch     string
+	Previous   *semver.Version
+	Current    *semver.Version
+	BumpType   BumpType
+	AllowMajor bool
+	// ReleaseOne is set with --release-1.0, which is the intended way to release 1.0.0,
+	// so allow-zero-major doesn't apply to it
+	ReleaseOne bool
+}
+
+func (p bumpPolicy) validate() error {
+	for _, rule := range p.Branches {
+		if _, err := path.Match(rule.Name, ""); err != nil {
+			return fmt.Errorf("invalid branch pattern %q: %w", rule.Name, err)
+		}
+		for _, bt := range rule.Allow {
+			switch bt {
+			case MajorBump, MinorBump, PatchBump, PrereleaseBump:
+			default:
+				return fmt.Errorf("unknown bump type %q for branch %s", bt, rule.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (r branchRule) allows(bumpType BumpType) bool {
+	for _, bt := range r.Allow {
+		if bt == bumpType {
+			return true
+		}
+	}
+	return false
+}
+
+// branchRule returns the first rule matching branch, or nil
+func (p bumpPolicy) branchRule(branch string) *branchRule {
+	for i, rule := range p.Branches {
+		if ok, _ := path.Match(rule.Name, branch); ok {
+			return &p.Branches[i]
+		}
+	}
+	return nil
+}
+
+func (p bumpPolicy) isMainBranch(branch string) bool {
+	for _, name := range p.MainBranches {
+		if name == branch {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns an error wrapping ErrPolicyViolation if the policy doesn't allow r
+func (p bumpPolicy) check(r bumpRequest) error {
+	if r.BumpType == MajorBump {
+		if p.RequireAllowMajor && !r.AllowMajor {
+			return ErrMajorNotAllowed
+		}
+		if !p.AllowZeroMajor && !r.ReleaseOne && r.Previous != nil && r.Previous.Major() == 0 {
+			return fmt.Errorf("%w: %s", ErrZeroMajorNotAllowed, r.Previous.Original())
+		}
+	}
+	if r.Branch == "" {
+		return nil
+	}
+	if rule := p.branchRule(r.Branch); rule != nil && r.BumpType != NoBump && !rule.allows(r.BumpType) {
+		allowed := make([]string, len(rule.Allow))
+		for i, bt := range rule.Allow {
+			allowed[i] = string(bt)
+		}
+		return fmt.Errorf("%w: %s bump on %s, only %s allowed", ErrBumpNotAllowed, r.BumpType, r.Branch, strings.Join(allowed, ", "))
+	}
+	if !p.AllowPrereleaseOnMain && r.Current.Prerelease() != "" && p.isMainBranch(r.Branch) {
+		return fmt.Errorf("%w: %s on %s", ErrPrereleaseOnMain, r.Current, r.Branch)
+	}
+	return nil
+}
+
+// currentBranch returns the branch given with --branch, the branch HEAD is on, or the
+// branch from the CI environment, in that order. It returns "" if there is none.
+func currentBranch(repo *goget.Repository) string {
+	if branch := viper.GetString("branch"); branch != "" {
+		return branch
+	}
+	if repo != nil {
+		if headRef, err := repo.Head(); err == nil && headRef.Name().IsBranch() {
+			return headRef.Name().Short()
+		}
+	}
+	for _, name := range branchEnvVars {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// enforcePolicy checks a bump against the policy in the config file. repo is used to
+// find the branch, and may be nil.
+func enforcePolicy(repo *goget.Repository, result *bumpResult) error {
+	c, err := loadConfig(cfgFile)
+	if err != nil {
+		return err
+	}
+	branch := currentBranch(repo)
+	explain("Checking the policy for a %s bump to %s on branch %q", result.BumpType, result.Current, branch)
+	return c.Policy.check(bumpRequest{
+		Branch:     branch,
+		Previous:   result.Previous,
+		Current:    result.Current,
+		BumpType:   result.BumpType,
+		AllowMajor: viper.GetBool("allow-major"),
+		ReleaseOne: viper.GetBool("release-1.0"),
+	})
+}
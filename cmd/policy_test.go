@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBumpPolicyCheck(t *testing.T) {
+	policy := bumpPolicy{
+		Branches: []branchRule{
+			{Name: "release/*", Allow: []BumpType{PatchBump, PrereleaseBump}},
+			{Name: "main", Allow: []BumpType{MajorBump, MinorBump, PatchBump, PrereleaseBump}},
+		},
+		AllowZeroMajor:        false,
+		AllowPrereleaseOnMain: false,
+		MainBranches:          []string{"main"},
+		RequireAllowMajor:     true,
+	}
+
+	tests := []struct {
+		name     string
+		request  bumpRequest
+		expected error
+	}{
+		{"patch on release branch", bumpRequest{Branch: "release/1.x", Previous: semver.MustParse("1.2.0"), Current: semver.MustParse("1.2.1"), BumpType: PatchBump}, nil},
+		{"minor on release branch", bumpRequest{Branch: "release/1.x", Previous: semver.MustParse("1.2.0"), Current: semver.MustParse("1.3.0"), BumpType: MinorBump}, ErrBumpNotAllowed},
+		{"unlisted branch", bumpRequest{Branch: "feature/x", Previous: semver.MustParse("1.2.0"), Current: semver.MustParse("1.3.0"), BumpType: MinorBump}, nil},
+		{"major without --allow-major", bumpRequest{Branch: "main", Previous: semver.MustParse("1.2.0"), Current: semver.MustParse("2.0.0"), BumpType: MajorBump}, ErrMajorNotAllowed},
+		{"major with --allow-major", bumpRequest{Branch: "main", Previous: semver.MustParse("1.2.0"), Current: semver.MustParse("2.0.0"), BumpType: MajorBump, AllowMajor: true}, nil},
+		{"0.x major", bumpRequest{Branch: "main", Previous: semver.MustParse("0.9.0"), Current: semver.MustParse("1.0.0"), BumpType: MajorBump, AllowMajor: true}, ErrZeroMajorNotAllowed},
+		{"0.x major with --release-1.0", bumpRequest{Branch: "main", Previous: semver.MustParse("0.9.0"), Current: semver.MustParse("1.0.0"), BumpType: MajorBump, AllowMajor: true, ReleaseOne: true}, nil},
+		{"prerelease on main", bumpRequest{Branch: "main", Previous: semver.MustParse("1.2.0"), Current: semver.MustParse("1.2.1-rc.1"), BumpType: PrereleaseBump}, ErrPrereleaseOnMain},
+		{"prerelease on feature branch", bumpRequest{Branch: "feature/x", Previous: semver.MustParse("1.2.0"), Current: semver.MustParse("1.2.1-rc.1"), BumpType: PrereleaseBump}, nil},
+		{"no branch", bumpRequest{Previous: semver.MustParse("1.2.0"), Current: semver.MustParse("1.2.1-rc.1"), BumpType: PrereleaseBump}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.check(tt.request)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expected)
+				assert.ErrorIs(t, err, ErrPolicyViolation)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "semvertool.yaml")
+	err := os.WriteFile(path, []byte(`policy:
+  require-allow-major: true
+  allow-prerelease-on-main: false
+  branches:
+    - name: release/*
+      allow: [patch, prerelease]
+`), 0o644)
+	assert.NoError(t, err)
+
+	c, err := loadConfig(path)
+	assert.NoError(t, err)
+	assert.True(t, c.Policy.RequireAllowMajor)
+	assert.False(t, c.Policy.AllowPrereleaseOnMain)
+	assert.True(t, c.Policy.AllowZeroMajor)
+	assert.Equal(t, []string{"main", "master"}, c.Policy.MainBranches)
+	assert.Equal(t, []branchRule{{Name: "release/*", Allow: []BumpType{PatchBump, PrereleaseBump}}}, c.Policy.Branches)
+}
+
+func TestLoadConfigDefault(t *testing.T) {
+	// There's no .semvertool.yaml in the package directory, so the defaults apply
+	c, err := loadConfig("")
+	assert.NoError(t, err)
+	assert.NoError(t, c.Policy.check(bumpRequest{Branch: "main", Current: semver.MustParse("2.0.0-rc.1"), BumpType: MajorBump}))
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLoadConfigInvalidBumpType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "semvertool.yaml")
+	err := os.WriteFile(path, []byte("policy:\n  branches:\n    - name: main\n      allow: [huge]\n"), 0o644)
+	assert.NoError(t, err)
+
+	_, err = loadConfig(path)
+	assert.Error(t, err)
+}
+
+func TestGitBumpEnforcesPolicy(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	_, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "semvertool.yaml")
+	err = os.WriteFile(path, []byte("policy:\n  require-allow-major: true\n"), 0o644)
+	assert.NoError(t, err)
+
+	oldCfgFile := cfgFile
+	cfgFile = path
+	defer func() { cfgFile = oldCfgFile }()
+	defer viper.Reset()
+
+	viper.Set("major", true)
+	_, err = gitBump(repo)
+	assert.ErrorIs(t, err, ErrMajorNotAllowed)
+
+	viper.Set("allow-major", true)
+	v, err := gitBump(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.0", v.String())
+}
+
+func TestGitBumpReleaseOneWithZeroMajorPolicy(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v0.5.0", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "semvertool.yaml")
+	err = os.WriteFile(path, []byte("policy:\n  allow-zero-major: false\n"), 0o644)
+	assert.NoError(t, err)
+
+	oldCfgFile := cfgFile
+	cfgFile = path
+	defer func() { cfgFile = oldCfgFile }()
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("major", true)
+	_, err = gitBump(repo)
+	assert.ErrorIs(t, err, ErrZeroMajorNotAllowed)
+
+	viper.Set("major", false)
+	viper.Set("release-1.0", true)
+	v, err := gitBump(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", v.String())
+}
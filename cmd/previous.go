@@ -48,12 +48,11 @@ func getPreviousTag(repo *goget.Repository, onlyReleased bool) (string, error) {
 		return "", fmt.Errorf("error getting HEAD: %w", err)
 	}
 
-	// Get all tags
-	tagRefs, err := repo.Tags()
+	// Get all tags, with annotated tags peeled to their commits
+	tags, err := getTagInfos(repo)
 	if err != nil {
 		return "", fmt.Errorf("error getting tags: %w", err)
 	}
-	defer tagRefs.Close()
 
 	// Map to store tag name -> commit hash
 	tagMap := make(map[string]plumbing.Hash)
@@ -62,31 +61,17 @@ func getPreviousTag(repo *goget.Repository, onlyReleased bool) (string, error) {
 	// Separate slice to store only released versions if needed
 	var releasedVersions []*semver.Version
 
-	// Read all tags and filter for valid semver
-	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
-		tagName := ref.Name().Short()
-
-		// Try to parse as semver
-		v, err := semver.NewVersion(tagName)
-		if err != nil {
-			// Skip if not a valid semver
-			return nil
-		}
+	for _, t := range tags {
+		v := t.Version
 
 		// Store the tag name and its target commit
-		tagMap[v.Original()] = ref.Hash()
+		tagMap[v.Original()] = t.Commit
 		semverTags = append(semverTags, v)
 
 		// If it's a released version, add to separate slice
 		if v.Prerelease() == "" && v.Metadata() == "" {
 			releasedVersions = append(releasedVersions, v)
 		}
-
-		return nil
-	})
-
-	if err != nil {
-		return "", fmt.Errorf("error iterating tags: %w", err)
 	}
 
 	if len(semverTags) == 0 {
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -295,3 +297,32 @@ func TestGetPreviousTagNoSemverTags(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "", prevTag)
 }
+
+func TestGetPreviousTagAnnotatedTags(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+
+	tagger := &object.Signature{Name: "Test Author", Email: "test_email@example.com", When: time.Now()}
+
+	commit1, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit1, &git.CreateTagOptions{Message: "Release v1.0.0", Tagger: tagger})
+	assert.NoError(t, err)
+
+	commit2, err := commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.1.0", commit2, &git.CreateTagOptions{Message: "Release v1.1.0", Tagger: tagger})
+	assert.NoError(t, err)
+
+	// HEAD is at the annotated v1.1.0, so the previous tag is v1.0.0
+	prevTag, err := getPreviousTag(repo, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", prevTag)
+
+	// After another commit, the annotated v1.1.0 is found in the history
+	_, err = commitFile("file3.txt", repo)
+	assert.NoError(t, err)
+	prevTag, err = getPreviousTag(repo, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.0", prevTag)
+}
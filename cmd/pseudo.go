@@ -0,0 +1,138 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/module"
+	gosemver "golang.org/x/mod/semver"
+)
+
+var (
+	pseudoRepoPath string
+	pseudoGoMod    string
+)
+
+// pseudoCmd represents the pseudo command
+var pseudoCmd = &cobra.Command{
+	Use:   "pseudo",
+	Short: "Get the Go pseudo-version of HEAD",
+	Long: `Get the Go pseudo-version of HEAD, as the go command would compute it.
+
+The pseudo-version is based on the highest semver tag reachable from HEAD,
+using the commit time and hash of HEAD:
+
+	vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdef123456   after release vX.Y.Z
+	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdef123456   after prerelease vX.Y.Z-pre
+	vX.0.0-yyyymmddhhmmss-abcdef123456         with no tag
+
+Only tags that are valid Go module versions for the module's major version
+are considered, which is taken from the module path in go.mod (so a module
+path ending in /v2 only uses v2 tags). If HEAD is tagged, the tag is returned
+instead.`,
+	Run: runPseudo,
+}
+
+func init() {
+	pseudoCmd.Flags().StringVarP(&pseudoRepoPath, "repository", "r", ".", "Path to the git repository (defaults to current directory)")
+	pseudoCmd.Flags().StringVar(&pseudoGoMod, "go-mod", "go.mod", "go.mod file to read the module path from")
+}
+
+// goPseudoVersion computes the pseudo-version of HEAD for a module whose path ends in
+// pathMajor ("" for v0/v1 modules, "/v2" and so on otherwise).
+func goPseudoVersion(repo *goget.Repository, pathMajor string) (string, error) {
+	headRef, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("error getting HEAD: %w", err)
+	}
+	head, err := repo.CommitObject(headRef.Hash())
+	if err != nil {
+		return "", fmt.Errorf("error getting HEAD commit: %w", err)
+	}
+
+	tagCommits, err := getTagCommits(repo)
+	if err != nil {
+		return "", fmt.Errorf("error getting tags: %w", err)
+	}
+
+	highestUsable := func(versions []*semver.Version, current *semver.Version) *semver.Version {
+		for _, v := range versions {
+			if ValidateGoVersion(v.Original()) != nil || module.CheckPathMajor(v.Original(), pathMajor) != nil {
+				continue
+			}
+			if current == nil || v.GreaterThan(current) {
+				current = v
+			}
+		}
+		return current
+	}
+
+	// A tagged HEAD doesn't need a pseudo-version
+	if tagged := highestUsable(tagCommits[head.Hash], nil); tagged != nil {
+		return tagged.Original(), nil
+	}
+
+	// Find the highest usable tag on any ancestor of HEAD
+	var base *semver.Version
+	commitIter, err := repo.Log(&goget.LogOptions{From: head.Hash})
+	if err != nil {
+		return "", fmt.Errorf("error getting commit history: %w", err)
+	}
+	defer commitIter.Close()
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		base = highestUsable(tagCommits[c.Hash], base)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error walking commit history: %w", err)
+	}
+
+	major := ""
+	older := ""
+	if base != nil {
+		older = base.Original()
+		major = gosemver.Major(older)
+	} else if pathMajor != "" {
+		major = pathMajor[1:]
+	}
+	rev := head.Hash.String()[:12]
+	return module.PseudoVersion(major, older, head.Committer.When, rev), nil
+}
+
+func runPseudo(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Unexpected arguments: %v\n", args)
+		_ = cmd.Help()
+		os.Exit(1)
+	}
+
+	pathMajor := ""
+	modPath, err := readGoModulePath(pseudoGoMod)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read module path: %s\n", err)
+		os.Exit(1)
+	}
+	if modPath != "" {
+		_, pathMajor, _ = module.SplitPathVersion(modPath)
+	}
+
+	repo, err := goget.PlainOpenWithOptions(pseudoRepoPath, &goget.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open git repository: %s\n", err)
+		os.Exit(1)
+	}
+
+	version, err := goPseudoVersion(repo, pathMajor)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error computing pseudo-version: %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(version)
+}
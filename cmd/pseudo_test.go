@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/mod/module"
+)
+
+func headCommit(t *testing.T, repo *git.Repository) *object.Commit {
+	ref, err := repo.Head()
+	assert.NoError(t, err)
+	commit, err := repo.CommitObject(ref.Hash())
+	assert.NoError(t, err)
+	return commit
+}
+
+func TestGoPseudoVersionAfterRelease(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	_, err := commitFile("file5.txt", repo)
+	assert.NoError(t, err)
+
+	head := headCommit(t, repo)
+	expected := "v1.2.1-0." + head.Committer.When.UTC().Format(module.PseudoVersionTimestampFormat) + "-" + head.Hash.String()[:12]
+
+	result, err := goPseudoVersion(repo, "")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	assert.True(t, IsGoPseudoVersion(result))
+}
+
+func TestGoPseudoVersionAfterPrerelease(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0-rc.1", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
+	head := headCommit(t, repo)
+	expected := "v1.0.0-rc.1.0." + head.Committer.When.UTC().Format(module.PseudoVersionTimestampFormat) + "-" + head.Hash.String()[:12]
+
+	result, err := goPseudoVersion(repo, "")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestGoPseudoVersionNoTags(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	_, err = commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+
+	head := headCommit(t, repo)
+	expected := "v2.0.0-" + head.Committer.When.UTC().Format(module.PseudoVersionTimestampFormat) + "-" + head.Hash.String()[:12]
+
+	result, err := goPseudoVersion(repo, "/v2")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestGoPseudoVersionIgnoresOtherMajorAndInvalidTags(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.1.0", commit, nil)
+	assert.NoError(t, err)
+	commit, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v2.0.0", commit, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("1.5.0", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file3.txt", repo)
+	assert.NoError(t, err)
+
+	result, err := goPseudoVersion(repo, "")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^v1\.1\.1-0\.\d{14}-[0-9a-f]{12}$`, result)
+}
+
+func TestGoPseudoVersionHeadTagged(t *testing.T) {
+	repo := setupRepoWithTags(t)
+
+	result, err := goPseudoVersion(repo, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.2.0", result)
+}
+
+func TestGoPseudoVersionIgnoresUnreachableTags(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	assert.NoError(t, err)
+
+	w, err := repo.Worktree()
+	assert.NoError(t, err)
+	err = w.Checkout(&git.CheckoutOptions{Create: true, Branch: "refs/heads/other"})
+	assert.NoError(t, err)
+	other, err := commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.5.0", other, nil)
+	assert.NoError(t, err)
+
+	err = w.Checkout(&git.CheckoutOptions{Branch: "refs/heads/master"})
+	assert.NoError(t, err)
+	_, err = commitFile("file3.txt", repo)
+	assert.NoError(t, err)
+
+	result, err := goPseudoVersion(repo, "")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^v1\.0\.1-0\.`, result)
+}
+
+func TestGetTagCommitsPeelsAnnotatedTags(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, &git.CreateTagOptions{
+		Message: "Release v1.0.0",
+		Tagger:  &object.Signature{Name: "Test Author", Email: "test_email@example.com"},
+	})
+	assert.NoError(t, err)
+
+	result, err := getTagCommits(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, VersionsToStrings(result[commit]))
+}
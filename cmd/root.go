@@ -34,7 +34,8 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.bump.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is "+defaultConfigFile+" in the current directory, if it exists)")
+	rootCmd.PersistentFlags().BoolVar(&strictTags, "strict-tags", false, "Fail on tags that look like versions but aren't valid semver, instead of warning")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -45,4 +46,15 @@ func init() {
 	rootCmd.AddCommand(SortCmd)
 
 	rootCmd.AddCommand(scriptCmd)
+	rootCmd.AddCommand(envCmd)
+	rootCmd.AddCommand(getCmd)
+	rootCmd.AddCommand(dockerTagsCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(pseudoCmd)
+	rootCmd.AddCommand(describeCmd)
+	rootCmd.AddCommand(tagsCmd)
+	rootCmd.AddCommand(auditTagsCmd)
+	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(linesCmd)
+	rootCmd.AddCommand(diffCmd)
 }
@@ -0,0 +1,270 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// bumpRule selects a bump type for commits that match all of its conditions. Rules
+// are used by bump git --from-commit, from the rules section of the config file.
+type bumpRule struct {
+	// Message is a regular expression matched against the commit message
+	Message string `mapstructure:"message"`
+	// Paths are glob patterns, one of which must match a path changed by the commit.
+	// A pattern ending in /** matches everything under a directory.
+	Paths []string `mapstructure:"paths"`
+	// Author is a regular expression matched against "Name <email>"
+	Author string `mapstructure:"author"`
+	// Trailer is the key of a git trailer the commit must have, such as Change-Type,
+	// and TrailerValue an optional regular expression its value must match
+	Trailer      string   `mapstructure:"trailer"`
+	TrailerValue string   `mapstructure:"trailer-value"`
+	Bump         BumpType `mapstructure:"bump"`
+}
+
+// bumpDecision is the bump chosen from the commits since the last tag, and why
+type bumpDecision struct {
+	BumpType BumpType
+	// Version is set for an ExplicitBump, from a marker such as [bump to 2.0.0]
+	Version *semver.Version
+	Commit  plumbing.Hash
+	Source  string
+}
+
+// outranks reports whether d should win over other. An explicit version wins over any
+// bump, and the highest explicit version over the others. A skip (NoBump) only wins
+// when nothing else matched.
+func (d *bumpDecision) outranks(other *bumpDecision) bool {
+	switch {
+	case d.Version != nil:
+		return other.Version == nil || d.Version.GreaterThan(other.Version)
+	case other.Version != nil:
+		return false
+	}
+	return d.BumpType.isHigherThan(other.BumpType)
+}
+
+var trailerLine = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s*(.*?)\s*$`)
+
+func (r bumpRule) validate() error {
+	if r.Message == "" && len(r.Paths) == 0 && r.Author == "" && r.Trailer == "" {
+		return fmt.Errorf("rule for %s bumps has no conditions", r.Bump)
+	}
+	switch r.Bump {
+	case MajorBump, MinorBump, PatchBump, PrereleaseBump:
+	default:
+		return fmt.Errorf("unknown bump type %q in rule %s", r.Bump, r)
+	}
+	for _, expr := range []string{r.Message, r.Author, r.TrailerValue} {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid regular expression %q in rule %s: %w", expr, r, err)
+		}
+	}
+	for _, p := range r.Paths {
+		if _, err := path.Match(strings.TrimSuffix(p, "/**"), ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q in rule %s: %w", p, r, err)
+		}
+	}
+	return nil
+}
+
+// String describes the rule's conditions
+func (r bumpRule) String() string {
+	conditions := make([]string, 0)
+	if r.Message != "" {
+		conditions = append(conditions, fmt.Sprintf("message %q", r.Message))
+	}
+	if len(r.Paths) > 0 {
+		conditions = append(conditions, "paths "+strings.Join(r.Paths, ", "))
+	}
+	if r.Author != "" {
+		conditions = append(conditions, fmt.Sprintf("author %q", r.Author))
+	}
+	if r.Trailer != "" && r.TrailerValue != "" {
+		conditions = append(conditions, fmt.Sprintf("trailer %s %q", r.Trailer, r.TrailerValue))
+	} else if r.Trailer != "" {
+		conditions = append(conditions, "trailer "+r.Trailer)
+	}
+	return "[" + strings.Join(conditions, "; ") + "]"
+}
+
+// matchPath matches a path against a glob pattern, where a pattern ending in /**
+// matches everything under a directory
+func matchPath(pattern, name string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+		for d := path.Dir(name); d != "."; d = path.Dir(d) {
+			if ok, _ := path.Match(dir, d); ok {
+				return true
+			}
+		}
+		return false
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
+
+// commitTrailers returns the git trailers in the last paragraph of a commit message
+func commitTrailers(message string) map[string][]string {
+	trailers := make(map[string][]string)
+	paragraphs := strings.Split(strings.TrimSpace(message), "\n\n")
+	if len(paragraphs) < 2 {
+		// A message with a single paragraph is all subject
+		return trailers
+	}
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		if m := trailerLine.FindStringSubmatch(line); m != nil {
+			key := strings.ToLower(m[1])
+			trailers[key] = append(trailers[key], m[2])
+		}
+	}
+	return trailers
+}
+
+// changedPaths returns the paths changed by a commit, compared to its first parent
+func changedPaths(c *object.Commit) ([]string, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var parentTree *object.Tree
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		parentTree, err = parent.Tree()
+		if err != nil {
+			return nil, err
+		}
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.From.Name != "" {
+			paths = append(paths, change.From.Name)
+		}
+		if change.To.Name != "" && change.To.Name != change.From.Name {
+			paths = append(paths, change.To.Name)
+		}
+	}
+	return paths, nil
+}
+
+// matches reports whether the commit meets all the rule's conditions
+func (r bumpRule) matches(c *object.Commit) (bool, error) {
+	if r.Message != "" && !regexp.MustCompile(r.Message).MatchString(c.Message) {
+		return false, nil
+	}
+	if r.Author != "" {
+		author := fmt.Sprintf("%s <%s>", c.Author.Name, c.Author.Email)
+		if !regexp.MustCompile(r.Author).MatchString(author) {
+			return false, nil
+		}
+	}
+	if r.Trailer != "" {
+		values := commitTrailers(c.Message)[strings.ToLower(r.Trailer)]
+		found := false
+		for _, value := range values {
+			if r.TrailerValue == "" || regexp.MustCompile(r.TrailerValue).MatchString(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	if len(r.Paths) > 0 {
+		paths, err := changedPaths(c)
+		if err != nil {
+			return false, fmt.Errorf("error getting the paths changed by %s: %w", c.Hash, err)
+		}
+		for _, p := range paths {
+			for _, pattern := range r.Paths {
+				if matchPath(pattern, p) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// bumpFromCommits walks the commits from HEAD back to base, and returns the decision
+// that outranks the others, from the rules or from the markers in the commit messages.
+// Commits with a skip marker such as [skip release] don't count towards a bump. It
+// returns nil if nothing matched.
+func bumpFromCommits(repo *goget.Repository, base plumbing.Hash, rules []bumpRule, markers []markerPattern) (*bumpDecision, error) {
+	headRef, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("error getting HEAD: %w", err)
+	}
+	released, err := ancestorsOf(repo, base)
+	if err != nil {
+		return nil, err
+	}
+	commitIter, err := repo.Log(&goget.LogOptions{From: headRef.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("error getting commit history: %w", err)
+	}
+	defer commitIter.Close()
+
+	var decision *bumpDecision
+	consider := func(d *bumpDecision) {
+		if decision == nil || d.outranks(decision) {
+			decision = d
+		}
+	}
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		if released[c.Hash] {
+			return nil
+		}
+		short := c.Hash.String()[:defaultSHALength]
+		explain("Scanning commit %s %s", short, strings.SplitN(c.Message, "\n", 2)[0])
+		marker, err := findMessageMarker(c.Message, markers)
+		if err != nil {
+			return err
+		}
+		if marker != nil {
+			explain("Commit %s has marker %q for %s", short, marker.Source, marker)
+			d := &bumpDecision{BumpType: marker.BumpType, Version: marker.Version, Commit: c.Hash, Source: fmt.Sprintf("%q", marker.Source)}
+			if marker.Version != nil {
+				d.BumpType = ExplicitBump
+			}
+			consider(d)
+			if marker.BumpType == NoBump && marker.Version == nil {
+				// A skipped commit doesn't match any rules
+				return nil
+			}
+		}
+		for i, rule := range rules {
+			ok, err := rule.matches(c)
+			if err != nil {
+				return err
+			}
+			if ok {
+				explain("Commit %s matches rule %d %s for a %s bump", short, i+1, rule, rule.Bump)
+				consider(&bumpDecision{BumpType: rule.Bump, Commit: c.Hash, Source: fmt.Sprintf("rule %d %s", i+1, rule)})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking commit history: %w", err)
+	}
+	return decision, nil
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// commitChange commits a new file with the given message and author
+func commitChange(t *testing.T, repo *git.Repository, filename, message, author string) plumbing.Hash {
+	w, err := repo.Worktree()
+	assert.NoError(t, err)
+	_, err = w.Filesystem.Create(filename)
+	assert.NoError(t, err)
+	_, err = w.Add(filename)
+	assert.NoError(t, err)
+	hash, err := w.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: author, Email: author + "@example.com", When: time.Now()},
+	})
+	assert.NoError(t, err)
+	return hash
+}
+
+func TestBumpRuleMatches(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commitChange(t, repo, "README.md", "Initial commit", "jane")
+	hash := commitChange(t, repo, "api/v1/service.proto", "Add a field\n\nChange-Type: feature\nLabels: api", "renovate")
+	commit, err := repo.CommitObject(hash)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		rule     bumpRule
+		expected bool
+	}{
+		{"message", bumpRule{Message: "(?i)add a"}, true},
+		{"message mismatch", bumpRule{Message: "breaking"}, false},
+		{"paths", bumpRule{Paths: []string{"api/**"}}, true},
+		{"paths glob", bumpRule{Paths: []string{"api/*/*.proto"}}, true},
+		{"paths mismatch", bumpRule{Paths: []string{"docs/**"}}, false},
+		{"author", bumpRule{Author: "^renovate <"}, true},
+		{"author mismatch", bumpRule{Author: "^jane"}, false},
+		{"trailer", bumpRule{Trailer: "change-type"}, true},
+		{"trailer value", bumpRule{Trailer: "Change-Type", TrailerValue: "^feature$"}, true},
+		{"trailer value mismatch", bumpRule{Trailer: "Change-Type", TrailerValue: "^breaking$"}, false},
+		{"all conditions", bumpRule{Message: "field", Paths: []string{"api/**"}, Author: "renovate"}, true},
+		{"one condition fails", bumpRule{Message: "field", Paths: []string{"api/**"}, Author: "jane"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := tt.rule.matches(commit)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
+
+func TestBumpRuleValidate(t *testing.T) {
+	assert.NoError(t, bumpRule{Paths: []string{"api/**"}, Bump: MinorBump}.validate())
+	assert.Error(t, bumpRule{Bump: MinorBump}.validate())
+	assert.Error(t, bumpRule{Message: "x", Bump: "huge"}.validate())
+	assert.Error(t, bumpRule{Message: "(", Bump: MinorBump}.validate())
+}
+
+func TestCommitTrailers(t *testing.T) {
+	trailers := commitTrailers("Subject: not a trailer\n\nBody text.\n\nSemver: minor\nSigned-off-by: Jane <jane@example.com>\n")
+	assert.Equal(t, []string{"minor"}, trailers["semver"])
+	assert.Equal(t, []string{"Jane <jane@example.com>"}, trailers["signed-off-by"])
+	assert.Empty(t, commitTrailers("Subject: not a trailer"))
+}
+
+func TestBumpFromCommits(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	base := commitChange(t, repo, "README.md", "Initial commit [bump major]", "jane")
+	_, err = repo.CreateTag("v1.0.0", base, nil)
+	assert.NoError(t, err)
+	commitChange(t, repo, "docs/guide.md", "Write docs", "jane")
+	commitChange(t, repo, "api/service.proto", "Add a field", "jane")
+	commitChange(t, repo, "main.go", "Fix a bug [bump patch]", "jane")
+
+	rules := []bumpRule{
+		{Paths: []string{"docs/**"}, Bump: PatchBump},
+		{Paths: []string{"api/**"}, Bump: MinorBump},
+	}
+	decision, err := bumpFromCommits(repo, base, rules, defaultMarkerPatterns)
+	assert.NoError(t, err)
+	assert.Equal(t, MinorBump, decision.BumpType)
+	assert.Equal(t, "rule 2 [paths api/**]", decision.Source)
+
+	// The [bump major] before the tag is already released
+	decision, err = bumpFromCommits(repo, base, nil, defaultMarkerPatterns)
+	assert.NoError(t, err)
+	assert.Equal(t, PatchBump, decision.BumpType)
+	assert.Equal(t, `"[bump patch]"`, decision.Source)
+
+	decision, err = bumpFromCommits(repo, base, nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, decision)
+}
+
+func TestGitBumpFromCommit(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	base := commitChange(t, repo, "README.md", "Initial commit", "jane")
+	_, err = repo.CreateTag("v1.0.0", base, nil)
+	assert.NoError(t, err)
+	commitChange(t, repo, "api/service.proto", "Add a field", "jane")
+
+	path := filepath.Join(t.TempDir(), "semvertool.yaml")
+	err = os.WriteFile(path, []byte("rules:\n  - paths: ['api/**']\n    bump: minor\n"), 0o644)
+	assert.NoError(t, err)
+	oldCfgFile := cfgFile
+	cfgFile = path
+	defer func() { cfgFile = oldCfgFile }()
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("from-commit", true)
+	result, err := gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Current.String())
+	assert.Equal(t, MinorBump, result.BumpType)
+}
+
+func TestBumpFromCommitsExplicitVersion(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	base := commitChange(t, repo, "README.md", "Initial commit", "jane")
+	_, err = repo.CreateTag("v1.1.0", base, nil)
+	assert.NoError(t, err)
+	commitChange(t, repo, "api/service.proto", "Add a field [bump major]", "jane")
+	release := commitChange(t, repo, "CHANGELOG.md", "Prepare the release [bump to 3.0.0]", "jane")
+	commitChange(t, repo, "docs/guide.md", "Write docs [skip release]", "jane")
+
+	decision, err := bumpFromCommits(repo, base, nil, defaultMarkerPatterns)
+	assert.NoError(t, err)
+	assert.Equal(t, ExplicitBump, decision.BumpType)
+	assert.Equal(t, "3.0.0", decision.Version.String())
+	assert.Equal(t, release, decision.Commit)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("from-commit", true)
+	result, err := gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.0.0", result.Current.String())
+	assert.Equal(t, MajorBump, result.BumpType)
+}
+
+func TestBumpFromCommitsSkip(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	base := commitChange(t, repo, "README.md", "Initial commit", "jane")
+	_, err = repo.CreateTag("v1.1.0", base, nil)
+	assert.NoError(t, err)
+	commitChange(t, repo, "api/service.proto", "Tidy up [skip release]", "jane")
+	commitChange(t, repo, "docs/guide.md", "Write docs [no bump]", "jane")
+
+	// The skipped commits don't match the rules
+	rules := []bumpRule{{Paths: []string{"api/**"}, Bump: MinorBump}}
+	decision, err := bumpFromCommits(repo, base, rules, defaultMarkerPatterns)
+	assert.NoError(t, err)
+	assert.Equal(t, NoBump, decision.BumpType)
+	assert.Nil(t, decision.Version)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("from-commit", true)
+	result, err := gitBumpWithResult(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.0", result.Current.Original())
+	assert.Equal(t, NoBump, result.BumpType)
+
+	// A bump on another commit wins over the skips
+	commitChange(t, repo, "main.go", "Fix a bug [bump patch]", "jane")
+	decision, err = bumpFromCommits(repo, base, rules, defaultMarkerPatterns)
+	assert.NoError(t, err)
+	assert.Equal(t, PatchBump, decision.BumpType)
+}
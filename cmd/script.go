@@ -8,9 +8,34 @@ import (
 	"os"
 
 	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
 	"github.com/spf13/cobra"
 )
 
+var (
+	compareIgnorePrerelease bool
+	compareIgnoreMetadata   bool
+	isLatestGit             bool
+	isLatestRepoPath        string
+)
+
+// Exit codes of the script commands. They don't overlap, so a script can tell a false
+// predicate from an error or a comparison result. diff --exit-code uses 21 to 25.
+const (
+	exitTrue  = 0
+	exitError = 1
+	exitFalse = 10
+	exitNewer = 11
+	exitOlder = 12
+)
+
+// CompareOptions selects the parts of the versions that CompareVersionsWithOptions ignores
+type CompareOptions struct {
+	IgnorePrerelease bool
+	// IgnoreMetadata has no effect, as metadata is always ignored under semver
+	IgnoreMetadata bool
+}
+
 // scriptCmd represents the script command
 var scriptCmd = &cobra.Command{
 	Use:   "script",
@@ -18,7 +43,14 @@ var scriptCmd = &cobra.Command{
 	Long: `Provides utilities for scripting with semantic versions.
 	
 These commands are designed to be used in shell scripts, returning exit codes
-that can be used in conditionals.`,
+that can be used in conditionals. Apart from the legacy released command, which
+returns 1 when false, they use these exit codes:
+
+ 0: true, or the versions are equal
+ 1: error
+ 10: false
+ 11: version1 > version2
+ 12: version1 < version2`,
 }
 
 // CompareVersions compares two semantic versions and returns:
@@ -27,6 +59,12 @@ that can be used in conditionals.`,
 // 12 if v2 is greater than v1 (v2 is newer)
 // Returns an error if either version is invalid
 func CompareVersions(v1string, v2string string) (int, error) {
+	return CompareVersionsWithOptions(v1string, v2string, CompareOptions{})
+}
+
+// CompareVersionsWithOptions compares two semantic versions like CompareVersions,
+// ignoring the prerelease with IgnorePrerelease, so that 1.0.0-rc.1 equals 1.0.0
+func CompareVersionsWithOptions(v1string, v2string string, opts CompareOptions) (int, error) {
 	v1, err := semver.NewVersion(v1string)
 	if err != nil {
 		return 0, fmt.Errorf("invalid version: %s", v1string)
@@ -37,15 +75,30 @@ func CompareVersions(v1string, v2string string) (int, error) {
 		return 0, fmt.Errorf("invalid version: %s", v2string)
 	}
 
+	v1 = stripVersion(v1, opts.IgnorePrerelease, opts.IgnoreMetadata)
+	v2 = stripVersion(v2, opts.IgnorePrerelease, opts.IgnoreMetadata)
+
 	if v1.LessThan(v2) {
-		return 12, nil // v2 is newer
+		return exitOlder, nil // v2 is newer
 	} else if v1.Equal(v2) {
-		return 0, nil // equal versions
+		return exitTrue, nil // equal versions
 	} else {
-		return 11, nil // v1 is newer
+		return exitNewer, nil // v1 is newer
 	}
 }
 
+// stripVersion returns v without its prerelease and/or metadata
+func stripVersion(v *semver.Version, prerelease, metadata bool) *semver.Version {
+	stripped := *v
+	if prerelease {
+		stripped, _ = stripped.SetPrerelease("")
+	}
+	if metadata {
+		stripped, _ = stripped.SetMetadata("")
+	}
+	return &stripped
+}
+
 // IsReleased checks if a version is a release version (no prerelease or metadata)
 // Returns true for release versions, false for prerelease versions or those with metadata
 // Returns an error if the version is invalid
@@ -58,6 +111,69 @@ func IsReleased(versionString string) (bool, error) {
 	return v.Prerelease() == "" && v.Metadata() == "", nil
 }
 
+// IsPrerelease checks if a version has a prerelease component
+func IsPrerelease(versionString string) (bool, error) {
+	v, err := semver.NewVersion(versionString)
+	if err != nil {
+		return false, fmt.Errorf("invalid version: %s", versionString)
+	}
+	return v.Prerelease() != "", nil
+}
+
+// HasMetadata checks if a version has build metadata
+func HasMetadata(versionString string) (bool, error) {
+	v, err := semver.NewVersion(versionString)
+	if err != nil {
+		return false, fmt.Errorf("invalid version: %s", versionString)
+	}
+	return v.Metadata() != "", nil
+}
+
+// IsMajorUpgrade checks if going from one version to another is an upgrade to a new
+// major version. 1.9.0 to 2.0.0-rc.1 is a major upgrade, 2.0.0 to 1.9.0 isn't.
+func IsMajorUpgrade(fromString, toString string) (bool, error) {
+	from, err := semver.NewVersion(fromString)
+	if err != nil {
+		return false, fmt.Errorf("invalid version: %s", fromString)
+	}
+	to, err := semver.NewVersion(toString)
+	if err != nil {
+		return false, fmt.Errorf("invalid version: %s", toString)
+	}
+	return to.Major() > from.Major(), nil
+}
+
+// IsLatest checks that no version in others is greater than the version. Invalid
+// versions in others are an error.
+func IsLatest(versionString string, others []string) (bool, error) {
+	v, err := semver.NewVersion(versionString)
+	if err != nil {
+		return false, fmt.Errorf("invalid version: %s", versionString)
+	}
+	for _, o := range others {
+		other, err := semver.NewVersion(o)
+		if err != nil {
+			return false, fmt.Errorf("invalid version: %s", o)
+		}
+		if other.GreaterThan(v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// exitPredicate exits with the exit code for the result of a predicate
+func exitPredicate(result bool, err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(exitError)
+	}
+	if result {
+		os.Exit(exitTrue)
+	}
+	os.Exit(exitFalse)
+}
+
 // compareCmd represents the compare subcommand
 var compareCmd = &cobra.Command{
 	Use:   "compare <version1> <version2>",
@@ -68,10 +184,16 @@ var compareCmd = &cobra.Command{
  11: version1 > version2
  12: version1 < version2
  
- If there is an error, the command will return 1.`,
+ If there is an error, the command will return 1.
+
+Build metadata is always ignored, as semver specifies. --ignore-prerelease
+also ignores the prerelease, so that 1.0.0-rc.1 and 1.0.0 are equal.`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		result, err := CompareVersions(args[0], args[1])
+		result, err := CompareVersionsWithOptions(args[0], args[1], CompareOptions{
+			IgnorePrerelease: compareIgnorePrerelease,
+			IgnoreMetadata:   compareIgnoreMetadata,
+		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%s\n", err)
 			os.Exit(1)
@@ -83,11 +205,14 @@ var compareCmd = &cobra.Command{
 // releasedCmd represents the released subcommand
 var releasedCmd = &cobra.Command{
 	Use:   "released <version>",
-	Short: "Check if a version is a release version",
+	Short: "Check if a version is a release version (legacy, see is-released)",
 	Long: `Check if a version is a release version (not a prerelease and has no metadata).
 	
 Returns exit code 0 if the version is a release version (X.Y.Z only),
-Returns exit code 1 if the version is a prerelease or has metadata.`,
+Returns exit code 1 if the version is a prerelease or has metadata.
+
+As 1 is also the exit code for errors, this is kept for existing scripts.
+Use is-released, which returns 10 when the version isn't a release.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		isReleased, err := IsReleased(args[0])
@@ -104,7 +229,100 @@ Returns exit code 1 if the version is a prerelease or has metadata.`,
 	},
 }
 
+// isReleasedCmd represents the is-released subcommand
+var isReleasedCmd = &cobra.Command{
+	Use:   "is-released <version>",
+	Short: "Check if a version is a release version",
+	Long: `Check if a version is a release version (not a prerelease and has no metadata).
+
+Returns exit code 0 if the version is a release version (X.Y.Z only), 10 if
+it is a prerelease or has metadata, and 1 if there is an error.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitPredicate(IsReleased(args[0]))
+	},
+}
+
+// isPrereleaseCmd represents the is-prerelease subcommand
+var isPrereleaseCmd = &cobra.Command{
+	Use:   "is-prerelease <version>",
+	Short: "Check if a version is a prerelease",
+	Long: `Check if a version has a prerelease component, such as 1.0.0-rc.1.
+
+Returns exit code 0 if the version is a prerelease, 10 if it isn't,
+and 1 if there is an error.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitPredicate(IsPrerelease(args[0]))
+	},
+}
+
+// hasMetadataCmd represents the has-metadata subcommand
+var hasMetadataCmd = &cobra.Command{
+	Use:   "has-metadata <version>",
+	Short: "Check if a version has build metadata",
+	Long: `Check if a version has build metadata, such as 1.0.0+build.5.
+
+Returns exit code 0 if the version has metadata, 10 if it doesn't,
+and 1 if there is an error.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitPredicate(HasMetadata(args[0]))
+	},
+}
+
+// isMajorUpgradeCmd represents the is-major-upgrade subcommand
+var isMajorUpgradeCmd = &cobra.Command{
+	Use:   "is-major-upgrade <from> <to>",
+	Short: "Check if going from one version to another is a major upgrade",
+	Long: `Check if the second version has a higher major version than the first.
+
+Returns exit code 0 if it is a major upgrade, 10 if it isn't,
+and 1 if there is an error.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitPredicate(IsMajorUpgrade(args[0], args[1]))
+	},
+}
+
+// isLatestCmd represents the is-latest subcommand
+var isLatestCmd = &cobra.Command{
+	Use:   "is-latest <version> [<version>...]",
+	Short: "Check if a version is the latest",
+	Long: `Check that no other version is greater than the first. The other versions
+are the remaining arguments, or the semver tags in the repository with --git.
+
+Returns exit code 0 if the version is the latest, 10 if it isn't,
+and 1 if there is an error.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		others := args[1:]
+		if isLatestGit {
+			repo, err := goget.PlainOpenWithOptions(isLatestRepoPath, &goget.PlainOpenOptions{DetectDotGit: true})
+			if err != nil {
+				exitPredicate(false, fmt.Errorf("failed to open git repository: %w", err))
+			}
+			tags, err := getTagsStrings(repo)
+			if err != nil {
+				exitPredicate(false, fmt.Errorf("error getting tags: %w", err))
+			}
+			others = append(others, tags...)
+		}
+		exitPredicate(IsLatest(args[0], others))
+	},
+}
+
 func init() {
+	compareCmd.Flags().BoolVar(&compareIgnorePrerelease, "ignore-prerelease", false, "Ignore the prerelease when comparing")
+	compareCmd.Flags().BoolVar(&compareIgnoreMetadata, "ignore-metadata", false, "Has no effect: build metadata is always ignored, as semver specifies")
+	isLatestCmd.Flags().BoolVar(&isLatestGit, "git", false, "Compare with the semver tags in the repository")
+	isLatestCmd.Flags().StringVarP(&isLatestRepoPath, "repository", "r", ".", "Path to the git repository for --git (defaults to current directory)")
+
 	scriptCmd.AddCommand(compareCmd)
 	scriptCmd.AddCommand(releasedCmd)
+	scriptCmd.AddCommand(isReleasedCmd)
+	scriptCmd.AddCommand(isPrereleaseCmd)
+	scriptCmd.AddCommand(hasMetadataCmd)
+	scriptCmd.AddCommand(isMajorUpgradeCmd)
+	scriptCmd.AddCommand(isLatestCmd)
 }
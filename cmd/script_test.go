@@ -82,3 +82,97 @@ func TestIsReleasedComplexVersion(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, result)
 }
+
+func TestCompareVersionsIgnorePrerelease(t *testing.T) {
+	result, err := CompareVersionsWithOptions("1.0.0-alpha", "1.0.0", CompareOptions{IgnorePrerelease: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result)
+
+	result, err = CompareVersionsWithOptions("1.0.1-alpha", "1.0.0", CompareOptions{IgnorePrerelease: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 11, result)
+}
+
+func TestCompareVersionsIgnoreMetadata(t *testing.T) {
+	result, err := CompareVersions("1.0.0+build.1", "1.0.0+build.2")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result)
+
+	result, err = CompareVersionsWithOptions("1.0.0-rc.1+build.1", "1.0.0-rc.2", CompareOptions{IgnoreMetadata: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 12, result)
+}
+
+func TestIsPrerelease(t *testing.T) {
+	result, err := IsPrerelease("1.0.0-rc.1+build.5")
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	result, err = IsPrerelease("1.0.0+build.5")
+	assert.NoError(t, err)
+	assert.False(t, result)
+
+	_, err = IsPrerelease("invalid")
+	assert.Error(t, err)
+}
+
+func TestHasMetadata(t *testing.T) {
+	result, err := HasMetadata("1.0.0+build.5")
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	result, err = HasMetadata("1.0.0-rc.1")
+	assert.NoError(t, err)
+	assert.False(t, result)
+
+	_, err = HasMetadata("invalid")
+	assert.Error(t, err)
+}
+
+func TestIsMajorUpgrade(t *testing.T) {
+	result, err := IsMajorUpgrade("1.9.0", "2.0.0-rc.1")
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	result, err = IsMajorUpgrade("1.2.0", "1.9.0")
+	assert.NoError(t, err)
+	assert.False(t, result)
+
+	result, err = IsMajorUpgrade("2.0.0", "1.9.0")
+	assert.NoError(t, err)
+	assert.False(t, result)
+
+	_, err = IsMajorUpgrade("1.0.0", "invalid")
+	assert.Error(t, err)
+}
+
+func TestIsLatest(t *testing.T) {
+	result, err := IsLatest("1.2.0", []string{"1.0.0", "v1.1.0", "1.2.0"})
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	result, err = IsLatest("1.2.0", []string{"1.0.0", "1.3.0-rc.1"})
+	assert.NoError(t, err)
+	assert.False(t, result)
+
+	result, err = IsLatest("1.2.0", nil)
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	_, err = IsLatest("1.2.0", []string{"invalid"})
+	assert.Error(t, err)
+}
+
+func TestScriptExitCodesDontOverlap(t *testing.T) {
+	codes := []int{exitTrue, exitError, exitFalse, exitNewer, exitOlder}
+	for _, code := range diffExitCodes {
+		if code != 0 {
+			codes = append(codes, code)
+		}
+	}
+	seen := map[int]bool{}
+	for _, code := range codes {
+		assert.False(t, seen[code], "exit code %d is used twice", code)
+		seen[code] = true
+	}
+}
@@ -2,18 +2,22 @@ package cmd
 
 import (
 	"fmt"
+	gosort "sort"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	goget "github.com/go-git/go-git/v5"
 	"github.com/jaevans/semvertool/pkg/sort"
 	"github.com/spf13/cobra"
+	gosemver "golang.org/x/mod/semver"
 )
 
 var (
 	order        string
 	noPrerelease bool
 	gitTags      bool
+	sortGo       bool
+	sortBy       string
 )
 
 // SortCmd represents the sort command
@@ -22,7 +26,10 @@ var SortCmd = &cobra.Command{
 	Short: "Sort a list of semver versions.",
 	Long: `Sort a list of semver versions provided on the command line or from git tags.
 
-Options include sorting order (ascending or descending) and filtering out prerelease versions.`,
+Options include sorting order (ascending or descending) and filtering out prerelease versions.
+
+Git tags can also be sorted by date with --sort-by date, which uses the tagger
+date of annotated tags and the commit date of lightweight tags.`,
 	RunE: RunSort,
 }
 
@@ -30,11 +37,88 @@ func init() {
 	SortCmd.Flags().StringVar(&order, "order", "ascending", "Sort order: ascending, asc, descending, or dsc")
 	SortCmd.Flags().BoolVar(&noPrerelease, "no-prerelease", false, "Exclude prerelease versions from the list")
 	SortCmd.Flags().BoolVar(&gitTags, "git", false, "Read versions from git tags instead of command-line arguments")
+	SortCmd.Flags().BoolVar(&sortGo, "go", false, "Sort Go module versions, following Go module conventions")
+	SortCmd.Flags().StringVar(&sortBy, "sort-by", "semver", "Sort key: semver, or date (only with --git)")
+}
+
+// sortTagsByDate sorts tags by their date, using semver ordering for tags with the same date
+func sortTagsByDate(tags []*tagInfo, ascending bool) {
+	gosort.SliceStable(tags, func(i, j int) bool {
+		a, b := tags[i], tags[j]
+		if !ascending {
+			a, b = b, a
+		}
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.Version.LessThan(b.Version)
+	})
+}
+
+// sortGoVersions sorts Go module versions with the same ordering as the go command.
+// Versions from git tags that aren't valid Go module versions are dropped, while
+// invalid command-line versions are an error.
+func sortGoVersions(versions []string, fromGit bool) ([]string, error) {
+	result := make([]string, 0, len(versions))
+	for _, v := range versions {
+		if err := ValidateGoVersion(v); err != nil {
+			if fromGit {
+				continue
+			}
+			return nil, err
+		}
+		if noPrerelease && gosemver.Prerelease(v) != "" {
+			continue
+		}
+		result = append(result, v)
+	}
+
+	gosemver.Sort(result)
+	if !(order == "ascending" || order == "asc") {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+	return result, nil
 }
 
 func RunSort(cmd *cobra.Command, args []string) error {
 	var versions []*semver.Version
 
+	switch sortBy {
+	case "semver":
+	case "date":
+		if !gitTags {
+			return fmt.Errorf("--sort-by date can only be used with --git")
+		}
+		if sortGo {
+			return fmt.Errorf("--sort-by date can't be used with --go")
+		}
+		return runSortByDate()
+	default:
+		return fmt.Errorf("invalid sort key: %s", sortBy)
+	}
+
+	if sortGo {
+		input := args
+		if gitTags {
+			repo, err := goget.PlainOpenWithOptions(".", &goget.PlainOpenOptions{DetectDotGit: true})
+			if err != nil {
+				return err
+			}
+			input, err = getTagsStrings(repo)
+			if err != nil {
+				return err
+			}
+		}
+		result, err := sortGoVersions(input, gitTags)
+		if err != nil {
+			return err
+		}
+		fmt.Println(strings.Join(result, " "))
+		return nil
+	}
+
 	if gitTags {
 		repo, err := goget.PlainOpenWithOptions(".", &goget.PlainOpenOptions{DetectDotGit: true})
 		if err != nil {
@@ -72,3 +156,31 @@ func RunSort(cmd *cobra.Command, args []string) error {
 	fmt.Println(strings.Join(result, " "))
 	return nil
 }
+
+func runSortByDate() error {
+	repo, err := goget.PlainOpenWithOptions(".", &goget.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return err
+	}
+	tags, err := getTagInfos(repo)
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]*tagInfo, 0, len(tags))
+	for _, t := range tags {
+		if noPrerelease && t.Version.Prerelease() != "" {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+
+	sortTagsByDate(filtered, order == "ascending" || order == "asc")
+
+	result := make([]string, len(filtered))
+	for i, t := range filtered {
+		result[i] = t.Version.Original()
+	}
+	fmt.Println(strings.Join(result, " "))
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -88,6 +90,57 @@ func TestSortRunSortNoPrerelease(t *testing.T) {
 	assert.Equal(t, expected, strings.TrimSuffix(buf.String(), "\n"))
 }
 
+func TestSortGoVersions(t *testing.T) {
+	versions := []string{"v1.2.0", "v0.0.0-20230905200255-921286631fa9", "v2.0.0+incompatible", "v1.2.1-0.20240101000000-abcdefabcdef"}
+	expected := []string{"v0.0.0-20230905200255-921286631fa9", "v1.2.0", "v1.2.1-0.20240101000000-abcdefabcdef", "v2.0.0+incompatible"}
+
+	result, err := sortGoVersions(versions, false)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestSortGoVersionsInvalidArgument(t *testing.T) {
+	_, err := sortGoVersions([]string{"v1.0.0", "1.1.0"}, false)
+	assert.Error(t, err)
+}
+
+func TestSortGoVersionsSkipsInvalidTags(t *testing.T) {
+	result, err := sortGoVersions([]string{"v1.0.0", "1.1.0"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, result)
+}
+
+func TestSortTagsByDate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	tags := []*tagInfo{
+		{Version: semver.MustParse("v2.0.0"), Date: day(1)},
+		{Version: semver.MustParse("v1.0.1"), Date: day(3)},
+		{Version: semver.MustParse("v1.1.0"), Date: day(2)},
+		{Version: semver.MustParse("v1.0.2"), Date: day(3)},
+	}
+
+	sortTagsByDate(tags, true)
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.Version.Original()
+	}
+	assert.Equal(t, []string{"v2.0.0", "v1.1.0", "v1.0.1", "v1.0.2"}, result)
+
+	sortTagsByDate(tags, false)
+	for i, tag := range tags {
+		result[i] = tag.Version.Original()
+	}
+	assert.Equal(t, []string{"v1.0.2", "v1.0.1", "v1.1.0", "v2.0.0"}, result)
+}
+
+func TestSortRunSortByDateWithoutGit(t *testing.T) {
+	oldSortBy := sortBy
+	sortBy = "date"
+	err := RunSort(&cobra.Command{}, []string{"1.0.0"})
+	sortBy = oldSortBy
+	assert.Error(t, err)
+}
+
 // func TestSortRunSortInvalidTag(t *testing.T) {
 // 	args := []string{"1.0.0", "2.0.0", "1.0.1-alpha.1", "invalid-tag"}
 // 	expected := "invalid semver version: invalid-tag"
@@ -0,0 +1,218 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	gosort "sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tagsRepoPath   string
+	tagsReleased   bool
+	tagsSince      string
+	tagsConstraint string
+	tagsOutput     string
+	tagsOrder      string
+)
+
+// tagsCmd represents the tags command
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List semver tags with their commit details",
+	Long: `List the semver tags in a git repository with their commit hash, date,
+author, tag type (lightweight or annotated), whether they are reachable from
+HEAD and whether they are a prerelease.
+
+The date is the tagger date of annotated tags, and the commit date of
+lightweight tags.
+
+Examples:
+	semvertool tags --released --since 2025-01-01
+	semvertool tags --constraint ">= 1.2, < 2" --output json`,
+	Run: runTags,
+}
+
+func init() {
+	tagsCmd.Flags().StringVarP(&tagsRepoPath, "repository", "r", ".", "Path to the git repository (defaults to current directory)")
+	tagsCmd.Flags().BoolVar(&tagsReleased, "released", false, "Only list released versions (no prerelease or metadata)")
+	tagsCmd.Flags().StringVar(&tagsSince, "since", "", "Only list tags dated on or after this date (YYYY-MM-DD or RFC 3339)")
+	tagsCmd.Flags().StringVar(&tagsConstraint, "constraint", "", "Only list versions matching a semver constraint, e.g. \">= 1.2, < 2\"")
+	tagsCmd.Flags().StringVarP(&tagsOutput, "output", "o", "table", "Output format: table or json")
+	tagsCmd.Flags().StringVar(&tagsOrder, "order", "ascending", "Sort order: ascending, asc, descending, or dsc")
+}
+
+// tagListing is one row of the tags output
+type tagListing struct {
+	Tag        string    `json:"tag"`
+	Commit     string    `json:"commit"`
+	Date       time.Time `json:"date"`
+	Author     string    `json:"author"`
+	Type       string    `json:"type"`
+	Reachable  bool      `json:"reachable"`
+	Prerelease bool      `json:"prerelease"`
+	Message    string    `json:"message,omitempty"`
+}
+
+// tagFilter selects which tags are listed. Zero values don't filter.
+type tagFilter struct {
+	released   bool
+	since      time.Time
+	constraint *semver.Constraints
+}
+
+func (f tagFilter) matches(t *tagInfo) bool {
+	if f.released && (t.Version.Prerelease() != "" || t.Version.Metadata() != "") {
+		return false
+	}
+	if !f.since.IsZero() && t.Date.Before(f.since) {
+		return false
+	}
+	if f.constraint != nil && !f.constraint.Check(t.Version) {
+		return false
+	}
+	return true
+}
+
+func parseSince(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or RFC 3339", s)
+	}
+	return t, nil
+}
+
+// reachableCommits returns the set of commits reachable from HEAD
+func reachableCommits(repo *goget.Repository) (map[plumbing.Hash]bool, error) {
+	reachable := make(map[plumbing.Hash]bool)
+	headRef, err := repo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		// No commits yet, so nothing is reachable
+		return reachable, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting HEAD: %w", err)
+	}
+	return ancestorsOf(repo, headRef.Hash())
+}
+
+// listTags returns the semver tags matching filter, in semver order
+func listTags(repo *goget.Repository, filter tagFilter, ascending bool) ([]tagListing, error) {
+	tags, err := getTagInfos(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tags: %w", err)
+	}
+	reachable, err := reachableCommits(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	gosort.SliceStable(tags, func(i, j int) bool {
+		if ascending {
+			return tags[i].Version.LessThan(tags[j].Version)
+		}
+		return tags[j].Version.LessThan(tags[i].Version)
+	})
+
+	listings := make([]tagListing, 0, len(tags))
+	for _, t := range tags {
+		if !filter.matches(t) {
+			continue
+		}
+		tagType := "lightweight"
+		if t.Annotated {
+			tagType = "annotated"
+		}
+		listings = append(listings, tagListing{
+			Tag:        t.Version.Original(),
+			Commit:     t.Commit.String(),
+			Date:       t.Date,
+			Author:     t.Author,
+			Type:       tagType,
+			Reachable:  reachable[t.Commit],
+			Prerelease: t.Version.Prerelease() != "",
+			Message:    t.Message,
+		})
+	}
+	return listings, nil
+}
+
+func writeTagsTable(out io.Writer, listings []tagListing) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TAG\tCOMMIT\tDATE\tAUTHOR\tTYPE\tREACHABLE\tPRERELEASE")
+	for _, l := range listings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%t\n",
+			l.Tag, l.Commit[:7], l.Date.Format("2006-01-02 15:04:05"), l.Author, l.Type, l.Reachable, l.Prerelease)
+	}
+	return w.Flush()
+}
+
+func writeTagsJSON(out io.Writer, listings []tagListing) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(listings)
+}
+
+func runTags(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Unexpected arguments: %v\n", args)
+		_ = cmd.Help()
+		os.Exit(1)
+	}
+
+	filter := tagFilter{released: tagsReleased}
+	if tagsSince != "" {
+		since, err := parseSince(tagsSince)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+		}
+		filter.since = since
+	}
+	if tagsConstraint != "" {
+		c, err := semver.NewConstraint(tagsConstraint)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid constraint %q: %s\n", tagsConstraint, err)
+			os.Exit(1)
+		}
+		filter.constraint = c
+	}
+
+	repo, err := goget.PlainOpenWithOptions(tagsRepoPath, &goget.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open git repository: %s\n", err)
+		os.Exit(1)
+	}
+
+	listings, err := listTags(repo, filter, tagsOrder == "ascending" || tagsOrder == "asc")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing tags: %s\n", err)
+		os.Exit(1)
+	}
+
+	switch tagsOutput {
+	case "table":
+		err = writeTagsTable(os.Stdout, listings)
+	case "json":
+		err = writeTagsJSON(os.Stdout, listings)
+	default:
+		err = fmt.Errorf("unknown output format: %s", tagsOutput)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func listingTags(listings []tagListing) []string {
+	result := make([]string, len(listings))
+	for i, l := range listings {
+		result[i] = l.Tag
+	}
+	return result
+}
+
+func TestListTags(t *testing.T) {
+	repo := setupRepoWithTags(t)
+
+	listings, err := listTags(repo, tagFilter{}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0", "v1.1.0", "v1.2.0-alpha.1", "v1.2.0"}, listingTags(listings))
+	for _, l := range listings {
+		assert.Equal(t, "lightweight", l.Type)
+		assert.True(t, l.Reachable)
+		assert.Len(t, l.Commit, 40)
+	}
+	assert.True(t, listings[2].Prerelease)
+	assert.False(t, listings[3].Prerelease)
+}
+
+func TestListTagsDescendingReleased(t *testing.T) {
+	repo := setupRepoWithTags(t)
+
+	listings, err := listTags(repo, tagFilter{released: true}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.2.0", "v1.1.0", "v1.0.0"}, listingTags(listings))
+}
+
+func TestListTagsConstraint(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	c, err := semver.NewConstraint(">= 1.1, < 1.2")
+	assert.NoError(t, err)
+
+	listings, err := listTags(repo, tagFilter{constraint: c}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.1.0"}, listingTags(listings))
+}
+
+func TestListTagsSinceAndAnnotated(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+
+	commit1, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit1, &git.CreateTagOptions{
+		Message: "Old release",
+		Tagger:  &object.Signature{Name: "Test Author", When: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	assert.NoError(t, err)
+	commit2, err := commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.1.0", commit2, &git.CreateTagOptions{
+		Message: "New release",
+		Tagger:  &object.Signature{Name: "Test Author", When: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)},
+	})
+	assert.NoError(t, err)
+
+	since, err := parseSince("2025-01-01")
+	assert.NoError(t, err)
+	listings, err := listTags(repo, tagFilter{since: since}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.1.0"}, listingTags(listings))
+	assert.Equal(t, "annotated", listings[0].Type)
+	assert.Equal(t, "New release\n", listings[0].Message)
+}
+
+func TestListTagsUnreachable(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	assert.NoError(t, err)
+
+	w, err := repo.Worktree()
+	assert.NoError(t, err)
+	err = w.Checkout(&git.CheckoutOptions{Create: true, Branch: plumbing.NewBranchReferenceName("other")})
+	assert.NoError(t, err)
+	other, err := commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v2.0.0", other, nil)
+	assert.NoError(t, err)
+	err = w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("master")})
+	assert.NoError(t, err)
+
+	listings, err := listTags(repo, tagFilter{}, true)
+	assert.NoError(t, err)
+	assert.True(t, listings[0].Reachable)
+	assert.False(t, listings[1].Reachable)
+}
+
+func TestParseSinceInvalid(t *testing.T) {
+	_, err := parseSince("last tuesday")
+	assert.Error(t, err)
+}
+
+func TestWriteTagsTableAndJSON(t *testing.T) {
+	listings := []tagListing{{
+		Tag:       "v1.0.0",
+		Commit:    "3f6d1270a1b2c3d4e5f60718293a4b5c6d7e8f90",
+		Date:      time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+		Author:    "Test Author",
+		Type:      "annotated",
+		Reachable: true,
+	}}
+
+	var table bytes.Buffer
+	assert.NoError(t, writeTagsTable(&table, listings))
+	assert.Contains(t, table.String(), "TAG")
+	assert.Contains(t, table.String(), "v1.0.0  3f6d127  2025-03-14 09:30:00  Test Author  annotated  true")
+
+	var out bytes.Buffer
+	assert.NoError(t, writeTagsJSON(&out, listings))
+	var decoded []tagListing
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, listings, decoded)
+}
@@ -3,10 +3,12 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	goget "github.com/go-git/go-git/v5"
@@ -24,8 +26,24 @@ const (
 	PrereleaseBump BumpType = "prerelease"
 	UnknownBump    BumpType = "unknown"
 	NoBump         BumpType = "none"
+	// ExplicitBump sets the version given in the commit message
+	ExplicitBump BumpType = "explicit"
 )
 
+// bumpTypeOrder ranks the bump types from least to most significant
+var bumpTypeOrder = map[BumpType]int{
+	NoBump:         1,
+	PrereleaseBump: 2,
+	PatchBump:      3,
+	MinorBump:      4,
+	MajorBump:      5,
+}
+
+// isHigherThan reports whether b is a more significant bump than other
+func (b BumpType) isHigherThan(other BumpType) bool {
+	return bumpTypeOrder[b] > bumpTypeOrder[other]
+}
+
 func extractBumpTypeFromMessage(s string) BumpType {
 	re := regexp.MustCompile(`(?i)\[bump\s*(major|minor|patch|prerelease)\s*\]`)
 
@@ -65,31 +83,125 @@ func extractTrailingDigits(s string) (string, int, error) {
 	return strings.TrimSuffix(matches[1], "."), number, err
 }
 
+// getBumpType returns the bump type from the flags, or UnknownBump if the commit
+// message can't be read
 func getBumpType() BumpType {
-	if fromMessage := viper.GetString("from-message"); fromMessage != "" {
-		messageBump := extractBumpTypeFromMessage(fromMessage)
-		if messageBump == UnknownBump || messageBump == NoBump {
-			fmt.Println("No valid bump type found in the commit message")
-		}
-		return messageBump
+	bumpType, _, err := getBumpTarget()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Could not read the commit message:", err)
+		return UnknownBump
+	}
+	return bumpType
+}
 
+// getBumpTarget returns the bump type from the flags, and for an ExplicitBump the
+// version given in the commit message
+func getBumpTarget() (BumpType, *semver.Version, error) {
+	if viper.GetString("from-message") != "" {
+		marker, err := getMessageMarker()
+		if err != nil {
+			return UnknownBump, nil, fmt.Errorf("could not read the commit message: %w", err)
+		}
+		if marker == nil {
+			fmt.Fprintln(os.Stderr, "No valid bump type found in the commit message")
+			return NoBump, nil, nil
+		}
+		reportMessageMarker(marker)
+		explain("Using %s from --from-message", marker)
+		if marker.Version != nil {
+			return ExplicitBump, marker.Version, nil
+		}
+		return marker.BumpType, nil, nil
 	}
-	if viper.GetBool("major") {
-		return MajorBump
+	for _, bumpType := range []BumpType{MajorBump, MinorBump, PatchBump, PrereleaseBump} {
+		if viper.GetBool(string(bumpType)) {
+			explain("Using %s bump from --%s", bumpType, bumpType)
+			return bumpType, nil, nil
+		}
 	}
-	if viper.GetBool("minor") {
+	explain("Using patch bump by default")
+	return PatchBump, nil, nil
+}
+
+// bumpTypeBetween returns the most significant part of the version that differs
+// between two versions, ignoring metadata
+func bumpTypeBetween(older, newer *semver.Version) BumpType {
+	switch {
+	case older.Major() != newer.Major():
+		return MajorBump
+	case older.Minor() != newer.Minor():
 		return MinorBump
-	}
-	if viper.GetBool("patch") {
+	case older.Patch() != newer.Patch():
 		return PatchBump
-	}
-	if viper.GetBool("prerelease") {
+	case older.Prerelease() != newer.Prerelease():
 		return PrereleaseBump
 	}
-	return PatchBump
+	return NoBump
 }
 
+var ErrAlreadyReleased = errors.New("version is already 1.0.0 or later")
+
+// initialDevelopmentBump applies the pre-1.0 options to a bump type. With
+// --initial-development, bump types are mapped down one level while the major version
+// is 0, so that breaking changes bump the minor version and features bump the patch
+// version, as many projects do under 0.y.z. Only --release-1.0 bumps 0.y.z to 1.0.0.
+func initialDevelopmentBump(version string, bumpType BumpType) (BumpType, error) {
+	scheme := viper.GetString("scheme")
+	if scheme != "" && scheme != "semver" {
+		if viper.GetBool("release-1.0") {
+			return bumpType, fmt.Errorf("--release-1.0 can't be used with --scheme %s", scheme)
+		}
+		return bumpType, nil
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		// Leave reporting the invalid version to the bump itself
+		return bumpType, nil
+	}
+	if viper.GetBool("release-1.0") {
+		if v.Major() != 0 {
+			return bumpType, fmt.Errorf("%w: %s", ErrAlreadyReleased, version)
+		}
+		explain("Using major bump from --release-1.0")
+		return MajorBump, nil
+	}
+	if !viper.GetBool("initial-development") || v.Major() != 0 {
+		return bumpType, nil
+	}
+	switch bumpType {
+	case MajorBump:
+		explain("Initial development: using minor bump instead of major for %s", version)
+		return MinorBump, nil
+	case MinorBump:
+		explain("Initial development: using patch bump instead of minor for %s", version)
+		return PatchBump, nil
+	}
+	return bumpType, nil
+}
+
+// doBump bumps version according to the versioning scheme selected with --scheme
 func doBump(version string, bumpWhat BumpType) (*semver.Version, error) {
+	switch bumpWhat {
+	case ExplicitBump:
+		return nil, fmt.Errorf("an explicit bump needs the version to set")
+	case UnknownBump:
+		return nil, fmt.Errorf("unknown bump type")
+	}
+	scheme := viper.GetString("scheme")
+	explain("Bumping %s with a %s bump", version, bumpWhat)
+	switch {
+	case (scheme == "" || scheme == "semver") && viper.GetBool("go"):
+		return goBump(version, bumpWhat)
+	case scheme == "" || scheme == "semver":
+		return doSemverBump(version, bumpWhat)
+	case strings.HasPrefix(scheme, calverSchemePrefix):
+		return calverBump(version, strings.TrimPrefix(scheme, calverSchemePrefix), bumpWhat)
+	}
+	return &semver.Version{}, fmt.Errorf("unknown versioning scheme: %s", scheme)
+}
+
+func doSemverBump(version string, bumpWhat BumpType) (*semver.Version, error) {
 	v, err := semver.NewVersion(version)
 	if err != nil {
 		return &semver.Version{}, err
@@ -144,9 +256,61 @@ func getCommonBumpFlags() *pflag.FlagSet {
 	commonFlags.Bool("prerelease", false, "Bump the prerelease version")
 	commonFlags.StringP("from-message", "m", "", "Extract the bump type from a commit message")
 	commonFlags.StringP("prerelease-prefix", "p", "prerelease", "Set the prefix for the prerelease version if there is no existing prefix.")
+	commonFlags.String("scheme", "semver", "Versioning scheme: semver, or calver:<format> such as calver:YYYY.MM.MICRO")
+	commonFlags.Bool("go", false, "Follow Go module version conventions (v prefix, +incompatible, module path major versions)")
+	commonFlags.String("go-mod", "go.mod", "go.mod file to check the module path against for major bumps in --go mode")
+	commonFlags.Bool("initial-development", false, "While the major version is 0, bump minor for breaking changes and patch for features")
+	commonFlags.Bool("release-1.0", false, "Release 1.0.0 from a 0.y.z version")
+	commonFlags.Bool("allow-major", false, "Allow a major bump when the policy in the config file requires it to be explicit")
+	commonFlags.String("branch", "", "Branch the policy in the config file is checked against (defaults to the current branch)")
+	commonFlags.Bool("dry-run", false, "Print what the bump would do (version, tag, commit, files written and pushes) without doing it")
+	commonFlags.BoolP("explain", "v", false, "Explain each step of choosing the new version on stderr")
+	commonFlags.Bool("ci-output", false, "Also write the new version and its components as CI outputs (GitHub Actions, GitLab CI or Azure Pipelines)")
 	return commonFlags
 }
 
+// explain prints a step of the bump decision to stderr with --explain
+func explain(format string, args ...any) {
+	if viper.GetBool("explain") {
+		fmt.Fprintf(os.Stderr, "explain: "+format+"\n", args...)
+	}
+}
+
+// strictTags makes malformed version tags an error rather than a warning
+var strictTags bool
+
+var ErrMalformedTag = errors.New("malformed version tag")
+
+// warnedTags are the malformed tags already reported, as the tags are read more than
+// once in a run and each should only be warned about once
+var warnedTags = make(map[string]bool)
+
+// versionLikeTag matches tags that are meant to be versions, so that a tag such as
+// v1.2.3.4 is reported as malformed while release-notes is quietly ignored
+var versionLikeTag = regexp.MustCompile(`^v?[0-9]`)
+
+// parseTag parses a tag name as a semver version. Tags that aren't versions return
+// nil without an error. Tags that look like versions but don't parse print a warning
+// on stderr and are skipped, or return ErrMalformedTag with --strict-tags.
+func parseTag(name string) (*semver.Version, error) {
+	v, err := semver.NewVersion(name)
+	if err == nil {
+		return v, nil
+	}
+	if !versionLikeTag.MatchString(name) {
+		explain("Skipping tag %s: not a version", name)
+		return nil, nil
+	}
+	if strictTags {
+		return nil, fmt.Errorf("%w %s: %s", ErrMalformedTag, name, err)
+	}
+	if !warnedTags[name] {
+		warnedTags[name] = true
+		fmt.Fprintf(os.Stderr, "Warning: could not parse tag %s as semver: %s\n", name, err)
+	}
+	return nil, nil
+}
+
 func getTags(repo *goget.Repository) ([]*semver.Version, error) {
 	iter, err := repo.Tags()
 	if err != nil {
@@ -155,12 +319,11 @@ func getTags(repo *goget.Repository) ([]*semver.Version, error) {
 	defer iter.Close()
 	semverTags := make([]*semver.Version, 0)
 	if err := iter.ForEach(func(ref *plumbing.Reference) error {
-		shortTag := ref.Name().Short()
-		t, err := semver.NewVersion(shortTag)
-		if err != nil {
-			fmt.Printf("Could not parse tag %s as semver: %s\n", shortTag, err)
-			return nil
+		t, err := parseTag(ref.Name().Short())
+		if err != nil || t == nil {
+			return err
 		}
+		explain("Found tag %s", t.Original())
 		semverTags = append(semverTags, t)
 		return nil
 	}); err != nil {
@@ -170,6 +333,79 @@ func getTags(repo *goget.Repository) ([]*semver.Version, error) {
 	return semverTags, nil
 }
 
+// tagInfo describes a semver tag and the commit it points at
+type tagInfo struct {
+	Version *semver.Version
+	// Commit is the tagged commit, with annotated tags peeled to their target
+	Commit    plumbing.Hash
+	Annotated bool
+	// Date is the tagger date of annotated tags, and the commit date of lightweight tags
+	Date    time.Time
+	Message string
+	// Author is the author of the tagged commit
+	Author string
+}
+
+// getTagInfos returns every semver tag in the repository along with its commit,
+// date and message. Tags that don't point at a commit are skipped.
+func getTagInfos(repo *goget.Repository) ([]*tagInfo, error) {
+	iter, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	tags := make([]*tagInfo, 0)
+	if err := iter.ForEach(func(ref *plumbing.Reference) error {
+		t, err := parseTag(ref.Name().Short())
+		if err != nil || t == nil {
+			return err
+		}
+		info := &tagInfo{Version: t}
+
+		tagObject, err := repo.TagObject(ref.Hash())
+		switch err {
+		case nil:
+			commit, err := tagObject.Commit()
+			if err != nil {
+				return nil
+			}
+			info.Commit = commit.Hash
+			info.Author = commit.Author.Name
+			info.Annotated = true
+			info.Date = tagObject.Tagger.When
+			info.Message = tagObject.Message
+		case plumbing.ErrObjectNotFound:
+			commit, err := repo.CommitObject(ref.Hash())
+			if err != nil {
+				return nil
+			}
+			info.Commit = commit.Hash
+			info.Author = commit.Author.Name
+			info.Date = commit.Committer.When
+		default:
+			return err
+		}
+		tags = append(tags, info)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// getTagCommits maps each commit to the semver tags that point at it
+func getTagCommits(repo *goget.Repository) (map[plumbing.Hash][]*semver.Version, error) {
+	tags, err := getTagInfos(repo)
+	if err != nil {
+		return nil, err
+	}
+	tagCommits := make(map[plumbing.Hash][]*semver.Version)
+	for _, t := range tags {
+		tagCommits[t.Commit] = append(tagCommits[t.Commit], t.Version)
+	}
+	return tagCommits, nil
+}
+
 func getTagsStrings(repo *goget.Repository) ([]string, error) {
 	tags, err := getTags(repo)
 	if err != nil {
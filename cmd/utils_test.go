@@ -467,3 +467,76 @@ func TestVersionsToStrings(t *testing.T) {
 	result := VersionsToStrings(entries)
 	assert.Equal(t, expected, result)
 }
+
+func TestInitialDevelopmentBump(t *testing.T) {
+	tests := []struct {
+		version  string
+		bumpType BumpType
+		expected BumpType
+	}{
+		{"0.4.2", MajorBump, MinorBump},
+		{"0.4.2", MinorBump, PatchBump},
+		{"0.4.2", PatchBump, PatchBump},
+		{"0.4.2", PrereleaseBump, PrereleaseBump},
+		{"1.4.2", MajorBump, MajorBump},
+		{"1.4.2", MinorBump, MinorBump},
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("initial-development", true)
+	for _, tt := range tests {
+		result, err := initialDevelopmentBump(tt.version, tt.bumpType)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, result, "%s %s", tt.bumpType, tt.version)
+	}
+}
+
+func TestInitialDevelopmentBumpDisabled(t *testing.T) {
+	viper.Reset()
+	result, err := initialDevelopmentBump("0.4.2", MajorBump)
+	assert.NoError(t, err)
+	assert.Equal(t, MajorBump, result)
+}
+
+func TestInitialDevelopmentBumpRelease(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("initial-development", true)
+	viper.Set("release-1.0", true)
+
+	result, err := initialDevelopmentBump("0.9.3", PatchBump)
+	assert.NoError(t, err)
+	assert.Equal(t, MajorBump, result)
+	v, err := doBump("0.9.3", result)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", v.String())
+
+	_, err = initialDevelopmentBump("1.0.0", PatchBump)
+	assert.ErrorIs(t, err, ErrAlreadyReleased)
+}
+
+func TestBumpTypeBetween(t *testing.T) {
+	tests := []struct {
+		older, newer string
+		expected     BumpType
+	}{
+		{"1.2.3", "2.0.0", MajorBump},
+		{"1.2.3", "1.3.0", MinorBump},
+		{"1.2.3", "1.2.4-rc.1", PatchBump},
+		{"1.2.4-rc.1", "1.2.4-rc.2", PrereleaseBump},
+		{"1.2.4+build.1", "1.2.4+build.2", NoBump},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, bumpTypeBetween(semver.MustParse(tt.older), semver.MustParse(tt.newer)), "%s to %s", tt.older, tt.newer)
+	}
+}
+
+func TestBumpTypeIsHigherThan(t *testing.T) {
+	assert.True(t, MajorBump.isHigherThan(MinorBump))
+	assert.True(t, MinorBump.isHigherThan(PatchBump))
+	assert.True(t, PatchBump.isHigherThan(PrereleaseBump))
+	assert.True(t, PrereleaseBump.isHigherThan(NoBump))
+	assert.False(t, PatchBump.isHigherThan(PatchBump))
+	assert.False(t, PatchBump.isHigherThan(MajorBump))
+}
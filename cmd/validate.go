@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/cobra"
+)
+
+var validateGo bool
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate <version>...",
+	Short: "Validate semver version strings",
+	Long: `Validate one or more semver version strings.
+
+A leading "v" is allowed, but otherwise versions must follow the semver 2.0.0
+specification. With --go, versions must follow the Go module conventions
+instead: a mandatory "v" prefix, no build metadata other than +incompatible
+on v2 and above. Pseudo-versions are accepted.
+
+Returns exit code 0 if every version is valid, and 1 otherwise. The reason each
+invalid version was rejected is printed on stderr.
+
+Examples:
+	semvertool validate 1.2.3 v2.0.0-rc.1+build.5
+	semvertool validate --go v2.1.0+incompatible v0.0.0-20230905200255-921286631fa9`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		valid := true
+		for _, arg := range args {
+			var err error
+			if validateGo {
+				err = ValidateGoVersion(arg)
+			} else {
+				err = ValidateVersion(arg)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n", err)
+				valid = false
+			}
+		}
+		if !valid {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	validateCmd.Flags().BoolVar(&validateGo, "go", false, "Validate against Go module version conventions")
+}
+
+// ValidateVersion checks that a version strictly follows semver 2.0.0, other than an
+// optional "v" prefix. Unlike the parsing used elsewhere it doesn't accept partial
+// versions such as 1.2.
+func ValidateVersion(v string) error {
+	if _, err := semver.StrictNewVersion(strings.TrimPrefix(v, "v")); err != nil {
+		return fmt.Errorf("invalid version %s: %s", v, err)
+	}
+	return nil
+}
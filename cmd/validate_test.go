@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVersionValid(t *testing.T) {
+	for _, v := range []string{"1.2.3", "v1.2.3", "1.0.0-alpha.1+build.5"} {
+		assert.NoError(t, ValidateVersion(v), v)
+	}
+}
+
+func TestValidateVersionInvalid(t *testing.T) {
+	for _, v := range []string{"1.2", "01.2.3", "1.2.3-", "invalid", ""} {
+		assert.Error(t, ValidateVersion(v), v)
+	}
+}
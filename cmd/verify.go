@@ -0,0 +1,132 @@
+/*
+Copyright © 2025 James Evans
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Masterminds/semver/v3"
+	goget "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/viper"
+)
+
+var (
+	ErrHeadAlreadyTagged = errors.New("HEAD is already tagged")
+	ErrTagExists         = errors.New("version already exists as a tag")
+)
+
+// releasePlan is what a bump would do, as printed by --dry-run
+type releasePlan struct {
+	Version string
+	// Tag and Commit are the tag a release of the version would use and the commit it
+	// would point at. Commit is zero outside a git repository.
+	Tag    string
+	Commit plumbing.Hash
+	// Files are the files that would be written
+	Files []string
+	// Pushes are the refs that would be pushed to a remote
+	Pushes []string
+}
+
+// latestReachableTag returns the highest semver tag on HEAD or its ancestors, or nil if
+// there is none
+func latestReachableTag(repo *goget.Repository) (*semver.Version, error) {
+	tags, err := getTagInfos(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tags: %w", err)
+	}
+	reachable, err := reachableCommits(repo)
+	if err != nil {
+		return nil, err
+	}
+	var latest *semver.Version
+	for _, t := range tags {
+		if reachable[t.Commit] && (latest == nil || t.Version.GreaterThan(latest)) {
+			latest = t.Version
+		}
+	}
+	return latest, nil
+}
+
+// verifyBump checks that a new version can be released from HEAD: HEAD mustn't be
+// tagged yet, the version mustn't exist as a tag, and it must be greater than the
+// latest tag reachable from HEAD.
+func verifyBump(repo *goget.Repository, v *semver.Version) error {
+	headRef, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("error getting HEAD: %w", err)
+	}
+	tags, err := getTagInfos(repo)
+	if err != nil {
+		return fmt.Errorf("error getting tags: %w", err)
+	}
+	for _, t := range tags {
+		if t.Commit == headRef.Hash() {
+			return fmt.Errorf("%w: %s", ErrHeadAlreadyTagged, t.Version.Original())
+		}
+	}
+	for _, t := range tags {
+		if t.Version.Equal(v) {
+			return fmt.Errorf("%w: %s", ErrTagExists, t.Version.Original())
+		}
+	}
+	latest, err := latestReachableTag(repo)
+	if err != nil {
+		return err
+	}
+	if latest != nil && !v.GreaterThan(latest) {
+		return fmt.Errorf("%w: %s is not greater than %s", ErrVersionNotGreater, v, latest.Original())
+	}
+	return nil
+}
+
+// planRelease works out what a bump would do. repo may be nil outside a git repository.
+func planRelease(repo *goget.Repository, r *bumpResult) (*releasePlan, error) {
+	plan := &releasePlan{Version: printedVersion(r.Current)}
+	// Tags follow the "v" prefix of the previous version
+	plan.Tag = r.Current.String()
+	if viper.GetBool("go") || (r.Previous != nil && strings.HasPrefix(r.Previous.Original(), "v")) {
+		plan.Tag = "v" + plan.Tag
+	}
+	if repo != nil {
+		headRef, err := repo.Head()
+		if err == nil {
+			plan.Commit = headRef.Hash()
+		} else if err != plumbing.ErrReferenceNotFound {
+			return nil, fmt.Errorf("error getting HEAD: %w", err)
+		}
+	}
+	if viper.GetBool("ci-output") {
+		if file := ciOutputFile(); file != "" {
+			plan.Files = append(plan.Files, file)
+		}
+	}
+	return plan, nil
+}
+
+func writeReleasePlan(out io.Writer, plan *releasePlan) error {
+	none := func(values []string) string {
+		if len(values) == 0 {
+			return "none"
+		}
+		return strings.Join(values, ", ")
+	}
+	commit := "none"
+	if !plan.Commit.IsZero() {
+		commit = plan.Commit.String()
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Version:\t%s\n", plan.Version)
+	fmt.Fprintf(w, "Tag:\t%s\n", plan.Tag)
+	fmt.Fprintf(w, "Commit:\t%s\n", commit)
+	fmt.Fprintf(w, "Files:\t%s\n", none(plan.Files))
+	fmt.Fprintf(w, "Pushes:\t%s\n", none(plan.Pushes))
+	return w.Flush()
+}
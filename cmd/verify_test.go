@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyBump(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	assert.NoError(t, err)
+
+	// HEAD is tagged
+	err = verifyBump(repo, semver.MustParse("1.0.1"))
+	assert.ErrorIs(t, err, ErrHeadAlreadyTagged)
+
+	commit, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+	assert.NoError(t, verifyBump(repo, semver.MustParse("1.0.1")))
+
+	err = verifyBump(repo, semver.MustParse("1.0.0"))
+	assert.ErrorIs(t, err, ErrTagExists)
+
+	err = verifyBump(repo, semver.MustParse("0.9.0"))
+	assert.ErrorIs(t, err, ErrVersionNotGreater)
+
+	// A tag that isn't reachable from HEAD still exists
+	_, err = repo.CreateTag("v1.1.0", commit, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file3.txt", repo)
+	assert.NoError(t, err)
+	err = verifyBump(repo, semver.MustParse("v1.1.0"))
+	assert.ErrorIs(t, err, ErrTagExists)
+}
+
+func TestLatestReachableTag(t *testing.T) {
+	repo, err := setupRepo()
+	assert.NoError(t, err)
+	commit1, err := commitFile("file1.txt", repo)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit1, nil)
+	assert.NoError(t, err)
+	_, err = commitFile("file2.txt", repo)
+	assert.NoError(t, err)
+
+	latest, err := latestReachableTag(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0", latest.Original())
+}
+
+func TestPlanRelease(t *testing.T) {
+	repo := setupRepoWithTags(t)
+	headRef, err := repo.Head()
+	assert.NoError(t, err)
+	viper.Reset()
+	defer viper.Reset()
+
+	result := &bumpResult{Previous: semver.MustParse("v1.2.0"), Current: semver.MustParse("1.3.0"), BumpType: MinorBump}
+	plan, err := planRelease(repo, result)
+	assert.NoError(t, err)
+	assert.Equal(t, &releasePlan{Version: "1.3.0", Tag: "v1.3.0", Commit: headRef.Hash()}, plan)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeReleasePlan(&buf, plan))
+	assert.Equal(t, "Version:  1.3.0\nTag:      v1.3.0\nCommit:   "+headRef.Hash().String()+"\nFiles:    none\nPushes:   none\n", buf.String())
+}
+
+func TestPlanReleaseCIOutput(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_OUTPUT", "/tmp/github_output")
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("ci-output", true)
+
+	result := &bumpResult{Previous: semver.MustParse("1.2.0"), Current: semver.MustParse("1.3.0"), BumpType: MinorBump}
+	plan, err := planRelease(nil, result)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.0", plan.Tag)
+	assert.True(t, plan.Commit.IsZero())
+	assert.Equal(t, []string{"/tmp/github_output"}, plan.Files)
+}